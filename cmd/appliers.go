@@ -1,8 +1,11 @@
 package cmd
 
 import (
+	"fmt"
+	"strings"
 	"time"
 
+	"github.com/legaard/uuidy/codec"
 	"github.com/spf13/cobra"
 )
 
@@ -10,6 +13,35 @@ const (
 	FlagNamespace = "namespace"
 	FlagNumber    = "number"
 	FlagEpoch     = "epoch"
+	FlagData      = "data"
+	FlagLayout    = "layout"
+	FlagField     = "field"
+	FlagOutput    = "output"
+	FlagFile      = "file"
+	FlagStrict    = "strict"
+	FlagVersion   = "version"
+	FlagFormat    = "format"
+	FlagFrom      = "from"
+	FlagTo        = "to"
+	FlagByteOrder = "byte-order"
+	FlagFromOrder = "from-order"
+	FlagToOrder   = "to-order"
+	FlagSeed      = "seed"
+	FlagStream    = "stream"
+	FlagRate      = "rate"
+	FlagDuration  = "duration"
+	FlagMethod    = "method"
+	FlagCounter   = "counter-bits"
+	FlagTimezone  = "tz"
+	FlagSQL       = "sql"
+	FlagColumn    = "column"
+	FlagToVersion = "to-version"
+	FlagMonotonic = "monotonic"
+	FlagPrefix    = "prefix"
+	FlagListen    = "listen"
+	FlagSocket    = "socket"
+	FlagShutdown  = "shutdown-timeout"
+	FlagMaxNumber = "max-number"
 )
 
 type FlagApplier func(cmd *cobra.Command)
@@ -19,14 +51,14 @@ func ApplyNamespaceFlag(defaultNs string) FlagApplier {
 		cmd.Flags().String(
 			FlagNamespace,
 			defaultNs,
-			"namespace used when generating value",
+			"namespace used when generating value (UUID, alias or configured name, e.g. dns:example.com/tenants)",
 		)
 	}
 }
 
 func ApplyNumberFlag() FlagApplier {
 	return func(cmd *cobra.Command) {
-		cmd.Flags().Uint32P(
+		cmd.Flags().Uint64P(
 			FlagNumber,
 			"n",
 			1,
@@ -39,8 +71,300 @@ func ApplyEpocTime() FlagApplier {
 	return func(cmd *cobra.Command) {
 		cmd.Flags().String(
 			FlagEpoch,
-			time.Now().Format(time.RFC3339Nano),
-			"epoch time used to generate value (format: RFC3339 nano)",
+			"",
+			"time used to generate values instead of the current time (RFC 3339, a date, a unix timestamp of at least 9 digits in s, ms, µs or ns, or a relative time such as -2h, yesterday or now+15m)",
+		)
+	}
+}
+
+func ApplyTimezoneFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagTimezone,
+			"",
+			"time zone of dates and times without a zone, e.g. UTC or Europe/Copenhagen (default local)",
+		)
+	}
+}
+
+func ApplyDataFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagData,
+			"",
+			"custom payload of up to 122 bits (format: hex)",
+		)
+	}
+}
+
+func ApplyLayoutFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagLayout,
+			"",
+			"layout of named bit fields in the custom payload (format: name:bits,name:bits)",
+		)
+	}
+}
+
+func ApplyFieldFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().StringToString(
+			FlagField,
+			nil,
+			"value of a layout field (format: name=value)",
+		)
+	}
+}
+
+func ApplyOutputFlag(formats ...string) FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().StringP(
+			FlagOutput,
+			"o",
+			formats[0],
+			fmt.Sprintf("output format (one of: %s)", strings.Join(formats, ", ")),
+		)
+	}
+}
+
+func ApplyFileFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().StringP(
+			FlagFile,
+			"f",
+			"",
+			"file with newline-delimited values to read instead of stdin",
+		)
+	}
+}
+
+func ApplyStrictFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Bool(
+			FlagStrict,
+			false,
+			"only accept canonical lowercase hyphenated values with RFC 9562 variant and known version",
+		)
+	}
+}
+
+func ApplyVersionFlag(defaultVersion uint8, usage string) FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Uint8(
+			FlagVersion,
+			defaultVersion,
+			usage,
+		)
+	}
+}
+
+func ApplyFormatFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagFormat,
+			FormatCanonical,
+			fmt.Sprintf("output format of generated values (one of: %s)", strings.Join(Formats, ", ")),
+		)
+	}
+}
+
+func ApplyFromFormatFlag(defaultFormat string) FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagFrom,
+			defaultFormat,
+			fmt.Sprintf("format of the input values (one of: %s, %s)", FormatAuto, strings.Join(Formats, ", ")),
+		)
+	}
+}
+
+func ApplyToFormatFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagTo,
+			FormatCanonical,
+			fmt.Sprintf("format of the output values (one of: %s)", strings.Join(Formats, ", ")),
+		)
+	}
+}
+
+func ApplyToVersionFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Uint8(
+			FlagToVersion,
+			0,
+			"convert V1 UUIDs to V6 (6), or V6 UUIDs to V1 (1), keeping timestamp, clock sequence and node (0 keeps the version)",
+		)
+	}
+}
+
+func ApplyFromTimeFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagFrom,
+			"",
+			"start of the time range (RFC 3339, a date, a unix timestamp of at least 9 digits or a relative time such as -2h or yesterday)",
+		)
+	}
+}
+
+func ApplyToTimeFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagTo,
+			"",
+			"end of the time range, inclusive (default --from)",
+		)
+	}
+}
+
+func ApplySQLFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Bool(
+			FlagSQL,
+			false,
+			"print the bounds as an SQL WHERE clause",
+		)
+	}
+}
+
+func ApplyColumnFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagColumn,
+			"id",
+			"column of the SQL WHERE clause",
+		)
+	}
+}
+
+func ApplyByteOrderFlag(name string) FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			name,
+			ByteOrderRFC,
+			fmt.Sprintf("byte order of the encoded bytes (one of: %s)", strings.Join(ByteOrders, ", ")),
+		)
+	}
+}
+
+func ApplySeedFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagSeed,
+			"",
+			"seed for a deterministic random stream, producing the same values on every run",
+		)
+	}
+}
+
+func ApplyStreamFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Bool(
+			FlagStream,
+			false,
+			"generate values until the output is closed or the process is interrupted, ignoring --number",
+		)
+	}
+}
+
+func ApplyRateFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Float64(
+			FlagRate,
+			0,
+			"maximum number of values generated per second when streaming (0 for no limit)",
+		)
+	}
+}
+
+func ApplyDurationFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Duration(
+			FlagDuration,
+			0,
+			"stop streaming after the duration, e.g. 30s or 5m (0 for no limit)",
+		)
+	}
+}
+
+func ApplyMethodFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagMethod,
+			MethodCounter,
+			fmt.Sprintf("method keeping values monotonic within a millisecond (one of: %s)", strings.Join(V7Methods, ", ")),
+		)
+	}
+}
+
+func ApplyCounterBitsFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Uint(
+			FlagCounter,
+			MaxCounterBits,
+			fmt.Sprintf("length of the counter of the counter method (%d-%d bits)", MinCounterBits, MaxCounterBits),
+		)
+	}
+}
+
+func ApplyMonotonicFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Bool(
+			FlagMonotonic,
+			false,
+			"increment the random bits of values generated within the same millisecond, keeping them sorted",
+		)
+	}
+}
+
+func ApplyPrefixFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagPrefix,
+			"",
+			fmt.Sprintf("type prefix of the TypeIDs (at most %d lowercase letters and underscores)", codec.MaxTypeIDPrefix),
+		)
+	}
+}
+
+func ApplyListenFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagListen,
+			"localhost:8080",
+			"TCP address to listen on",
+		)
+	}
+}
+
+func ApplySocketFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().String(
+			FlagSocket,
+			"",
+			"path of a unix socket to listen on instead of --listen",
+		)
+	}
+}
+
+func ApplyShutdownTimeoutFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Duration(
+			FlagShutdown,
+			10*time.Second,
+			"time to wait for requests in flight to complete when shutting down",
+		)
+	}
+}
+
+func ApplyMaxNumberFlag() FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().Uint64(
+			FlagMaxNumber,
+			1000,
+			"maximum number of values generated per request",
 		)
 	}
 }
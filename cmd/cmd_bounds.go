@@ -0,0 +1,177 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/codec"
+	"github.com/legaard/uuidy/generate"
+	"github.com/spf13/cobra"
+)
+
+func BoundsCmd() *cobra.Command {
+	var (
+		applyFlags = MergeAppliers(
+			ApplyFromTimeFlag(),
+			ApplyToTimeFlag(),
+			ApplyTimezoneFlag(),
+			ApplyVersionFlag(7, "version of the UUIDs (one of: 1, 6, 7)"),
+			ApplyFormatFlag(),
+			ApplyByteOrderFlag(FlagByteOrder),
+			ApplySQLFlag(),
+			ApplyColumnFlag(),
+		)
+		cmd = &cobra.Command{
+			Use:   "bounds",
+			Short: "Print the smallest and largest UUID for a time range",
+			Long: "Prints the smallest UUID for the time of --from and the largest UUID for the time of --to (default\n" +
+				"--from), one per line, so time-ordered primary keys can be queried by time. Both ends are inclusive,\n" +
+				"at the precision of the version: milliseconds for V7 and 100 nanoseconds for V1 and V6.\n\n" +
+				"Times are given like --epoch of the generators: RFC 3339, a date, a unix timestamp or a relative time\n" +
+				"such as -2h or yesterday. With --sql, the bounds are printed as a WHERE clause instead. V1 UUIDs only\n" +
+				"sort by time when stored in the mysql byte order, and V6 and V7 UUIDs only in the rfc byte order, so\n" +
+				"other combinations cannot be used in a range query.",
+			Example: "uuid bounds --from 2025-01-18T13:10:05+01:00\n" +
+				"uuid bounds --from yesterday --to today --sql\n" +
+				"uuid bounds --version 6 --from -1h --to now --sql --column order_id",
+			Args: cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				fromStr, err := cmd.Flags().GetString(FlagFrom)
+				if err != nil {
+					return err
+				}
+
+				toStr, err := cmd.Flags().GetString(FlagTo)
+				if err != nil {
+					return err
+				}
+
+				version, err := cmd.Flags().GetUint8(FlagVersion)
+				if err != nil {
+					return err
+				}
+
+				format, err := cmd.Flags().GetString(FlagFormat)
+				if err != nil {
+					return err
+				}
+
+				order, err := cmd.Flags().GetString(FlagByteOrder)
+				if err != nil {
+					return err
+				}
+
+				sql, err := cmd.Flags().GetBool(FlagSQL)
+				if err != nil {
+					return err
+				}
+
+				column, err := cmd.Flags().GetString(FlagColumn)
+				if err != nil {
+					return err
+				}
+
+				loc, err := locationOf(cmd)
+				if err != nil {
+					return err
+				}
+
+				if fromStr == "" {
+					return fmt.Errorf("--%s is required", FlagFrom)
+				}
+
+				if toStr == "" {
+					toStr = fromStr
+				}
+
+				var now = time.Now()
+
+				from, err := generate.ParseTime(fromStr, now, loc)
+				if err != nil {
+					return fmt.Errorf("invalid --%s: %w", FlagFrom, err)
+				}
+
+				to, err := generate.ParseTime(toStr, now, loc)
+				if err != nil {
+					return fmt.Errorf("invalid --%s: %w", FlagTo, err)
+				}
+
+				if to.Before(from) {
+					return fmt.Errorf("--%s %s is before --%s %s", FlagTo, to.Format(time.RFC3339Nano), FlagFrom, from.Format(time.RFC3339Nano))
+				}
+
+				lower, upper, err := generate.Bounds(version, from, to)
+				if err != nil {
+					return err
+				}
+
+				var enc = codec.Encoding{Format: format, Order: order}
+
+				if !sql {
+					return writeMany(2, cmd.OutOrStdout(), enc, func() (uuid.UUID, error) {
+						value := lower
+						lower = upper
+						return value, nil
+					})
+				}
+
+				if err = checkSortable(version, order); err != nil {
+					return err
+				}
+
+				lowerLiteral, err := sqlLiteral(enc, lower)
+				if err != nil {
+					return err
+				}
+
+				upperLiteral, err := sqlLiteral(enc, upper)
+				if err != nil {
+					return err
+				}
+
+				cmd.Printf("WHERE %s BETWEEN %s AND %s", column, lowerLiteral, upperLiteral)
+
+				return nil
+			},
+		}
+	)
+
+	applyFlags(cmd)
+
+	return cmd
+}
+
+// checkSortable returns an error unless values of the version sort by time
+// in the byte order, as needed for a range query.
+func checkSortable(version uint8, order string) error {
+	switch {
+	case version == 1 && order != ByteOrderMySQL:
+		return fmt.Errorf("V1 UUIDs do not sort by time unless stored in the %s byte order", ByteOrderMySQL)
+	case version != 1 && order != ByteOrderRFC:
+		return fmt.Errorf("V%d UUIDs do not sort by time in the %s byte order", version, order)
+	}
+
+	return nil
+}
+
+// sqlLiteral returns the value as an SQL literal: hex as a binary literal,
+// integers unquoted and the other formats as quoted strings.
+func sqlLiteral(enc codec.Encoding, value uuid.UUID) (string, error) {
+	encoded, err := enc.Encode(value)
+	if err != nil {
+		return "", err
+	}
+
+	switch codec.Resolve(enc.Format) {
+	case FormatHex:
+		return "X'" + encoded + "'", nil
+	case FormatInteger:
+		return encoded, nil
+	case FormatBinary, FormatJava:
+		return "", fmt.Errorf("the %s format cannot be used in SQL", enc.Format)
+	default:
+		return "'" + strings.ReplaceAll(encoded, "'", "''") + "'", nil
+	}
+}
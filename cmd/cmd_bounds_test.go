@@ -0,0 +1,174 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/cmd"
+	"github.com/legaard/uuidy/internal/assert"
+)
+
+func TestBoundsCmd(t *testing.T) {
+	t.Run(`use is "bounds"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.BoundsCmd()
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "bounds", actual)
+	})
+
+	t.Run("print V7 bounds of an instant", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.BoundsCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagFrom, "2025-01-18T13:10:05+01:00")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "01947952-0148-7000-8000-000000000000\n01947952-0148-7fff-bfff-ffffffffffff", output.String())
+	})
+
+	t.Run("print bounds containing generated UUIDs", func(t *testing.T) {
+		var (
+			from  = time.Date(2025, 1, 18, 12, 10, 5, 0, time.UTC)
+			at    = from.Add(500 * time.Millisecond)
+			to    = from.Add(time.Second)
+			gen   = uuid.NewGen()
+			v1, _ = gen.NewV1AtTime(at)
+			v6, _ = gen.NewV6AtTime(at)
+			v7, _ = gen.NewV7AtTime(at)
+		)
+
+		for version, value := range map[string]uuid.UUID{"1": v1, "6": v6, "7": v7} {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				sut    = cmd.BoundsCmd()
+			)
+			sut.SetOut(output)
+			_ = sut.Flags().Set(cmd.FlagVersion, version)
+			_ = sut.Flags().Set(cmd.FlagFrom, from.Format(time.RFC3339Nano))
+			_ = sut.Flags().Set(cmd.FlagTo, to.Format(time.RFC3339Nano))
+
+			// act
+			err := sut.RunE(sut, nil)
+
+			// assert
+			assert.NoErrorf(t, err, "version %s", version)
+
+			bounds := strings.Split(output.String(), "\n")
+			assert.Equal(t, 2, len(bounds))
+
+			lower, upper := uuid.FromStringOrNil(bounds[0]), uuid.FromStringOrNil(bounds[1])
+			assert.Equalf(t, value.Version(), lower.Version(), "version %s", version)
+			assert.Equalf(t, value.Version(), upper.Version(), "version %s", version)
+
+			// V1 does not sort by time, so compare the timestamps instead
+			if version == "1" {
+				lowerTs, _ := uuid.TimestampFromV1(lower)
+				upperTs, _ := uuid.TimestampFromV1(upper)
+				valueTs, _ := uuid.TimestampFromV1(value)
+				assert.Equal(t, true, lowerTs <= valueTs && valueTs <= upperTs)
+				continue
+			}
+
+			assert.Equalf(t, true, bytes.Compare(lower[:], value[:]) < 0, "version %s: %s below %s", version, value, lower)
+			assert.Equalf(t, true, bytes.Compare(value[:], upper[:]) < 0, "version %s: %s above %s", version, value, upper)
+		}
+	})
+
+	t.Run("print bounds as SQL", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.BoundsCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagFrom, "2025-01-18")
+		_ = sut.Flags().Set(cmd.FlagTo, "2025-01-19")
+		_ = sut.Flags().Set(cmd.FlagTimezone, "UTC")
+		_ = sut.Flags().Set(cmd.FlagSQL, "true")
+		_ = sut.Flags().Set(cmd.FlagColumn, "order_id")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "WHERE order_id BETWEEN '019476b5-9800-7000-8000-000000000000' AND '01947bdb-f400-7fff-bfff-ffffffffffff'", output.String())
+	})
+
+	t.Run("print V1 bounds as SQL in the mysql byte order", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.BoundsCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagVersion, "1")
+		_ = sut.Flags().Set(cmd.FlagFrom, "2025-01-18T12:10:05Z")
+		_ = sut.Flags().Set(cmd.FlagSQL, "true")
+		_ = sut.Flags().Set(cmd.FlagByteOrder, cmd.ByteOrderMySQL)
+		_ = sut.Flags().Set(cmd.FlagFormat, cmd.FormatHex)
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "WHERE id BETWEEN X'11efd59526d34c808000000000000000' AND X'11efd59526d34c80bfffffffffffffff'", output.String())
+	})
+
+	t.Run("return error on V1 bounds as SQL", func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.BoundsCmd()
+		)
+		_ = sut.Flags().Set(cmd.FlagVersion, "1")
+		_ = sut.Flags().Set(cmd.FlagFrom, "2025-01-18T12:10:05Z")
+		_ = sut.Flags().Set(cmd.FlagSQL, "true")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error on invalid range", func(t *testing.T) {
+		for name, flags := range map[string]map[string]string{
+			"missing from":        {},
+			"to before from":      {cmd.FlagFrom: "now", cmd.FlagTo: "-1h"},
+			"unsupported version": {cmd.FlagFrom: "now", cmd.FlagVersion: "4"},
+			"invalid time":        {cmd.FlagFrom: "soon"},
+		} {
+			// arrange
+			var (
+				sut = cmd.BoundsCmd()
+			)
+			for flag, value := range flags {
+				_ = sut.Flags().Set(flag, value)
+			}
+
+			// act
+			err := sut.RunE(sut, nil)
+
+			// assert
+			assert.Errorf(t, err, name)
+		}
+	})
+}
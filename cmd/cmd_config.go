@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+func ConfigCmd(settings *Settings) *cobra.Command {
+	return &cobra.Command{
+		Use:   "config [command]",
+		Short: "Show the effective configuration",
+		Long: "Shows the effective values of the flags of a command (default the root command) and where each came from.\n\n" +
+			"Values are layered, each layer overriding the previous ones:\n" +
+			"  1. built-in defaults\n" +
+			"  2. user config file ($XDG_CONFIG_HOME/uuidy/config.yaml, default ~/.config/uuidy/config.yaml)\n" +
+			"  3. project config file (" + ProjectConfigName + " in the working directory or its parents)\n" +
+			"  4. environment variables (" + EnvPrefix + "<FLAG>, e.g. " + envName(FlagByteOrder) + " for --" + FlagByteOrder + ")\n" +
+			"  5. flags given on the command line\n\n" +
+			"Values can be set for a single command under flags.<command> in a config file, or with " + EnvPrefix + "<COMMAND>_<FLAG>\n" +
+			"(e.g. " + envName("bounds_"+FlagFrom) + "), taking precedence over values for all commands. The flags " + strings.Join(commandFlags, ", ") + "\n" +
+			"mean different things in different commands and can only be set for a single command, so " + envName(FlagVersion) + "\n" +
+			"and the like are ignored.",
+		Example: "uuid config\n" +
+			"uuid config v7",
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var target = cmd.Root()
+			if len(args) == 1 {
+				found, _, err := target.Find(args)
+				if err != nil {
+					return err
+				}
+
+				target = found
+			}
+
+			var sb strings.Builder
+
+			sb.WriteString("files:\n")
+			for _, file := range settings.files {
+				sb.WriteString(fmt.Sprintf("  %s\n", file))
+			}
+
+			sb.WriteString(fmt.Sprintf("default_version: %s (%s)\n", settings.defaultVersion.value, settings.defaultVersion.source))
+
+			sb.WriteString("namespaces:\n")
+			names := make([]string, 0, len(settings.namespaces))
+			for name := range settings.namespaces {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				sb.WriteString(fmt.Sprintf("  %s: %s\n", name, settings.namespaces[name]))
+			}
+
+			sb.WriteString(fmt.Sprintf("flags (%s):\n", target.CommandPath()))
+			target.Flags().VisitAll(func(f *pflag.Flag) {
+				if f.Name == "help" {
+					return
+				}
+
+				source := SourceDefault
+				if value, ok, err := settings.flag(settingsName(target), f.Name); err == nil && ok {
+					source = value.source
+				}
+
+				sb.WriteString(fmt.Sprintf("  %s: %s (%s)\n", f.Name, f.Value.String(), source))
+			})
+
+			cmd.Print(sb.String())
+
+			return nil
+		},
+	}
+}
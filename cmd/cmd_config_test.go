@@ -0,0 +1,272 @@
+package cmd_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/cmd"
+	"github.com/legaard/uuidy/internal/assert"
+)
+
+func TestConfigCmd(t *testing.T) {
+	var (
+		dir     = t.TempDir()
+		user    = filepath.Join(dir, "config.yaml")
+		project = filepath.Join(dir, ".uuidy.yaml")
+	)
+
+	assert.NoError(t, os.WriteFile(user, []byte("default_version: 7\nflags:\n  number: 2\n  format: upper\n"), 0o600))
+	assert.NoError(t, os.WriteFile(project, []byte("flags:\n  format: base58\n"), 0o600))
+
+	env := func(values map[string]string) func(string) (string, bool) {
+		return func(key string) (string, bool) {
+			value, ok := values[key]
+			return value, ok
+		}
+	}
+
+	t.Run("later layers take precedence", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings([]string{user, project, filepath.Join(dir, "missing.yaml")}, env(map[string]string{
+			"UUIDY_NUMBER": "3",
+		}))
+		assert.NoError(t, err)
+
+		var (
+			v4 = cmd.V4Cmd(rand.Reader)
+		)
+
+		// act
+		cmd.ApplySettings(settings)(v4)
+
+		// assert
+		assert.NoError(t, settings.Err(v4))
+		assert.Equal(t, "7", settings.DefaultVersion())
+
+		number, _ := v4.Flags().GetUint64(cmd.FlagNumber)
+		assert.Equal(t, uint64(3), number)
+
+		format, _ := v4.Flags().GetString(cmd.FlagFormat)
+		assert.Equal(t, "base58", format)
+	})
+
+	t.Run("flags take precedence over settings", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings([]string{user}, env(nil))
+		assert.NoError(t, err)
+
+		var (
+			output = &bytes.Buffer{}
+			v4     = cmd.V4Cmd(rand.Reader)
+		)
+		v4.SetOut(output)
+		v4.SetArgs([]string{"-n", "1"})
+		cmd.ApplySettings(settings)(v4)
+
+		// act
+		err = v4.Execute()
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(strings.Split(output.String(), "\n")))
+		assert.Equal(t, strings.ToUpper(output.String()), output.String())
+	})
+
+	t.Run("invalid values are reported", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings(nil, env(map[string]string{
+			"UUIDY_NUMBER": "many",
+		}))
+		assert.NoError(t, err)
+
+		var (
+			v4    = cmd.V4Cmd(rand.Reader)
+			parse = cmd.ParseCmd()
+		)
+
+		// act
+		cmd.ApplySettings(settings)(v4)
+		cmd.ApplySettings(settings)(parse)
+
+		// assert
+		assert.Error(t, settings.Err(v4))
+		assert.NoError(t, settings.Err(parse))
+	})
+
+	t.Run("values for a command take precedence", func(t *testing.T) {
+		// arrange
+		var scoped = filepath.Join(dir, "scoped.yaml")
+		assert.NoError(t, os.WriteFile(scoped, []byte("flags:\n  format: hex\n  bounds:\n    from: 2025-01-18T12:27:25.397Z\n    format: upper\n"), 0o600))
+
+		settings, err := cmd.LoadSettings([]string{scoped}, env(map[string]string{
+			"UUIDY_CONVERT_TO": "base58",
+		}))
+		assert.NoError(t, err)
+
+		var (
+			bounds  = cmd.BoundsCmd()
+			convert = cmd.ConvertCmd()
+		)
+
+		// act
+		cmd.ApplySettings(settings)(bounds)
+		cmd.ApplySettings(settings)(convert)
+
+		// assert
+		assert.NoError(t, settings.Err(bounds))
+		assert.NoError(t, settings.Err(convert))
+
+		from, _ := bounds.Flags().GetString(cmd.FlagFrom)
+		assert.Equal(t, "2025-01-18T12:27:25.397Z", from)
+
+		format, _ := bounds.Flags().GetString(cmd.FlagFormat)
+		assert.Equal(t, "upper", format)
+
+		convertFrom, _ := convert.Flags().GetString(cmd.FlagFrom)
+		assert.Equal(t, cmd.FormatAuto, convertFrom)
+
+		to, _ := convert.Flags().GetString(cmd.FlagTo)
+		assert.Equal(t, "base58", to)
+	})
+
+	t.Run("values are passed to flags as written", func(t *testing.T) {
+		// arrange
+		var written = filepath.Join(dir, "written.yaml")
+		assert.NoError(t, os.WriteFile(written, []byte("flags:\n  seed: 0755\n  epoch: 2025-01-01\n  v8:\n    data: 0x1234abcd\n"), 0o600))
+
+		settings, err := cmd.LoadSettings([]string{written}, env(nil))
+		assert.NoError(t, err)
+
+		var (
+			output = &bytes.Buffer{}
+			v1     = cmd.V1Cmd(rand.Reader)
+			v8     = cmd.V8Cmd()
+		)
+		v8.SetOut(output)
+		v8.SetArgs([]string{})
+		cmd.ApplySettings(settings)(v1)
+		cmd.ApplySettings(settings)(v8)
+
+		// act
+		err = v8.Execute()
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, settings.Err(v8))
+		assert.Equal(t, "00000000-0000-8000-8000-00001234abcd", output.String())
+
+		seed, _ := v1.Flags().GetString(cmd.FlagSeed)
+		assert.Equal(t, "0755", seed)
+
+		epoch, _ := v1.Flags().GetString(cmd.FlagEpoch)
+		assert.Equal(t, "2025-01-01", epoch)
+	})
+
+	t.Run("values of flags differing between commands for all commands are reported", func(t *testing.T) {
+		// arrange
+		var global = filepath.Join(dir, "global.yaml")
+		assert.NoError(t, os.WriteFile(global, []byte("flags:\n  from: yesterday\n"), 0o600))
+
+		settings, err := cmd.LoadSettings([]string{global}, env(nil))
+		assert.NoError(t, err)
+
+		var (
+			convert = cmd.ConvertCmd()
+			v5      = cmd.V5Cmd(uuid.NamespaceDNS, nil)
+		)
+
+		// act
+		cmd.ApplySettings(settings)(convert)
+		cmd.ApplySettings(settings)(v5)
+
+		// assert
+		assert.Error(t, settings.Err(convert))
+		assert.NoError(t, settings.Err(v5))
+	})
+
+	t.Run("environment variables of flags differing between commands for all commands are ignored", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings(nil, env(map[string]string{
+			"UUIDY_OUTPUT":  "json",
+			"UUIDY_VERSION": "1.2.0",
+		}))
+		assert.NoError(t, err)
+
+		var (
+			bounds = cmd.BoundsCmd()
+			v5     = cmd.V5Cmd(uuid.NamespaceDNS, nil)
+		)
+
+		// act
+		cmd.ApplySettings(settings)(bounds)
+		cmd.ApplySettings(settings)(v5)
+
+		// assert
+		assert.NoError(t, settings.Err(bounds))
+		assert.NoError(t, settings.Err(v5))
+
+		version, _ := bounds.Flags().GetUint8(cmd.FlagVersion)
+		assert.Equal(t, uint8(7), version)
+
+		output, _ := v5.Flags().GetString(cmd.FlagOutput)
+		assert.Equal(t, cmd.OutputText, output)
+	})
+
+	t.Run("map flags given on the command line replace settings", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings(nil, env(map[string]string{
+			"UUIDY_FIELD": "shard=1",
+		}))
+		assert.NoError(t, err)
+
+		var (
+			output = &bytes.Buffer{}
+			v8     = cmd.V8Cmd()
+		)
+		v8.SetOut(output)
+		v8.SetArgs([]string{"--layout", "x:4", "--field", "x=1"})
+		cmd.ApplySettings(settings)(v8)
+
+		// act
+		err = v8.Execute()
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "10000000-0000-8000-8000-000000000000", output.String())
+	})
+
+	t.Run("show effective values and sources", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings([]string{user, project}, env(map[string]string{
+			"UUIDY_NUMBER": "3",
+		}))
+		assert.NoError(t, err)
+
+		var (
+			output = &bytes.Buffer{}
+			root   = cmd.RootCmd(cmd.V4Cmd(rand.Reader))
+			sut    = cmd.ConfigCmd(settings)
+		)
+		root.AddCommand(sut)
+		root.SetOut(output)
+		root.SetArgs([]string{"config"})
+		cmd.ApplySettings(settings)(root)
+
+		// act
+		err = root.Execute()
+
+		// assert
+		assert.NoError(t, err)
+
+		actual := output.String()
+		assert.Equal(t, true, strings.Contains(actual, "default_version: 7 ("+user+")\n"))
+		assert.Equal(t, true, strings.Contains(actual, "  number: 3 (env UUIDY_NUMBER)\n"))
+		assert.Equal(t, true, strings.Contains(actual, "  format: base58 ("+project+")\n"))
+		assert.Equal(t, true, strings.Contains(actual, "  byte-order: rfc (default)\n"))
+	})
+}
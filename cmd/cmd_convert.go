@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/codec"
+	"github.com/legaard/uuidy/generate"
+	"github.com/spf13/cobra"
+)
+
+func ConvertCmd() *cobra.Command {
+	var (
+		applyFlags = MergeAppliers(
+			ApplyFromFormatFlag(FormatAuto),
+			ApplyToFormatFlag(),
+			ApplyByteOrderFlag(FlagFromOrder),
+			ApplyByteOrderFlag(FlagToOrder),
+			ApplyFileFlag(),
+			ApplyToVersionFlag(),
+		)
+		cmd = &cobra.Command{
+			Use:   "convert [value...]",
+			Short: "Convert UUID values between formats",
+			Long: "Converts UUID values from one format to another. Without values, newline-delimited values are read\n" +
+				"from stdin (or --file); with --from bytes the input is read as raw 16 byte values instead.\n\n" +
+				"The input format is detected unless --from is given. Detection is based on length and alphabet, so\n" +
+				"values that fit several formats are read as the most likely one: a 22 char value only using characters\n" +
+				"shared by base58 and base64 is read as base58, and a 26 char value that could be both base32 and\n" +
+				"crockford is rejected as ambiguous.\n\n" +
+				"The byte orders of the input and output can be set to match how databases store UUIDs: guid for the\n" +
+				"mixed-endian SQL Server uniqueidentifier and mysql for UUID_TO_BIN(value, 1). Use the java format for\n" +
+				"the signed mostSigBits,leastSigBits pair of java.util.UUID and bytea for PostgreSQL bytea literals.\n\n" +
+				"With --to-version, V1 UUIDs are converted to V6 and back, reordering the timestamp while keeping the\n" +
+				"clock sequence and node. Values already of the target version are left as they are.",
+			Example: "uuid convert --to base58 01ebb00e-d38a-11ef-8f83-426648c33d81\n" +
+				"uuid convert --from base64 --to canonical AeuwDtOKEe+Pg0JmSMM9gQ==\n" +
+				"uuid convert --to hex --to-order mysql 01ebb00e-d38a-11ef-8f83-426648c33d81\n" +
+				"uuid convert --to-version 6 01ebb00e-d38a-11ef-8f83-426648c33d81",
+			Args: cobra.ArbitraryArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				from, err := cmd.Flags().GetString(FlagFrom)
+				if err != nil {
+					return err
+				}
+
+				to, err := cmd.Flags().GetString(FlagTo)
+				if err != nil {
+					return err
+				}
+
+				fromOrder, err := cmd.Flags().GetString(FlagFromOrder)
+				if err != nil {
+					return err
+				}
+
+				toOrder, err := cmd.Flags().GetString(FlagToOrder)
+				if err != nil {
+					return err
+				}
+
+				toVersion, err := cmd.Flags().GetUint8(FlagToVersion)
+				if err != nil {
+					return err
+				}
+
+				if toVersion != 0 && toVersion != 1 && toVersion != 6 {
+					return fmt.Errorf("unsupported version %d: must be 1 or 6", toVersion)
+				}
+
+				var (
+					input  = codec.Encoding{Format: from, Order: fromOrder}
+					output = codec.Encoding{Format: to, Order: toOrder}
+					writer = bufio.NewWriter(cmd.OutOrStdout())
+					count  int
+				)
+
+				convert := func(value string) error {
+					parsed, decodeErr := input.Decode(value)
+					if decodeErr != nil {
+						return decodeErr
+					}
+
+					if toVersion != 0 {
+						if parsed, decodeErr = generate.ConvertVersion(parsed, toVersion); decodeErr != nil {
+							return decodeErr
+						}
+					}
+
+					encoded, encodeErr := output.Encode(parsed)
+					if encodeErr != nil {
+						return encodeErr
+					}
+
+					if count > 0 {
+						encoded = output.Separator() + encoded
+					}
+					count++
+
+					_, writeErr := io.WriteString(writer, encoded)
+
+					return writeErr
+				}
+
+				switch {
+				case len(args) > 0:
+					for _, arg := range args {
+						if err = convert(arg); err != nil {
+							return err
+						}
+					}
+				case codec.Resolve(from) == FormatBinary:
+					err = readChunks(cmd, uuid.Size, func(chunk []byte) error {
+						return convert(string(chunk))
+					})
+				default:
+					err = readLines(cmd, func(number int, line string) error {
+						if convertErr := convert(line); convertErr != nil {
+							return fmt.Errorf("line %d: %w", number, convertErr)
+						}
+
+						return nil
+					})
+				}
+				if err != nil {
+					return err
+				}
+
+				return writer.Flush()
+			},
+		}
+	)
+
+	applyFlags(cmd)
+
+	return cmd
+}
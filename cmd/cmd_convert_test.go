@@ -0,0 +1,337 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/cmd"
+	"github.com/legaard/uuidy/internal/assert"
+)
+
+func TestConvertCmd(t *testing.T) {
+	const value = "05b16a01-46c6-56dd-bd6e-c6dfb4a1427a"
+
+	t.Run(`use is "convert [value...]"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.ConvertCmd()
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "convert [value...]", actual)
+	})
+
+	for format, input := range map[string]string{
+		cmd.FormatUpper:     "05B16A01-46C6-56DD-BD6E-C6DFB4A1427A",
+		cmd.FormatHex:       "05b16a0146c656ddbd6ec6dfb4a1427a",
+		cmd.FormatBraces:    "{05b16a01-46c6-56dd-bd6e-c6dfb4a1427a}",
+		cmd.FormatURN:       "urn:uuid:05b16a01-46c6-56dd-bd6e-c6dfb4a1427a",
+		cmd.FormatBase64:    "BbFqAUbGVt29bsbftKFCeg==",
+		cmd.FormatBase32:    "AWYWUAKGYZLN3PLOY3P3JIKCPI",
+		cmd.FormatBase58:    "hmtWcLBWDqJdAF5upbo6Z",
+		cmd.FormatCrockford: "05P5N02HP6AVEVTVP6VYTA2GKT",
+		cmd.FormatInteger:   "7567326559435121659774093805196755578",
+	} {
+		t.Run(fmt.Sprintf("detect %s format", format), func(t *testing.T) {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				sut    = cmd.ConvertCmd()
+			)
+			sut.SetOut(output)
+
+			// act
+			err := sut.RunE(sut, []string{input})
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, value, output.String())
+		})
+
+		t.Run(fmt.Sprintf("convert to %s format", format), func(t *testing.T) {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				sut    = cmd.ConvertCmd()
+			)
+			sut.SetOut(output)
+			_ = sut.Flags().Set(cmd.FlagTo, format)
+
+			// act
+			err := sut.RunE(sut, []string{value})
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, input, output.String())
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		to, toOrder, expected string
+	}{
+		"SQL Server GUID bytes": {cmd.FormatHex, cmd.ByteOrderGUID, "016ab105c646dd56bd6ec6dfb4a1427a"},
+		"MySQL swapped bytes":   {cmd.FormatHex, cmd.ByteOrderMySQL, "56dd46c605b16a01bd6ec6dfb4a1427a"},
+		"Java long pair":        {cmd.FormatJava, cmd.ByteOrderRFC, "410225594782340829,-4796677888992525702"},
+		"PostgreSQL bytea":      {cmd.FormatBytea, cmd.ByteOrderRFC, `\x05b16a0146c656ddbd6ec6dfb4a1427a`},
+	} {
+		t.Run(fmt.Sprintf("round trip %s", name), func(t *testing.T) {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				back   = &bytes.Buffer{}
+				sut    = cmd.ConvertCmd()
+				revSut = cmd.ConvertCmd()
+			)
+			sut.SetOut(output)
+			_ = sut.Flags().Set(cmd.FlagTo, tc.to)
+			_ = sut.Flags().Set(cmd.FlagToOrder, tc.toOrder)
+			revSut.SetOut(back)
+			_ = revSut.Flags().Set(cmd.FlagFromOrder, tc.toOrder)
+
+			// act
+			err := sut.RunE(sut, []string{value})
+			revErr := revSut.RunE(revSut, []string{output.String()})
+
+			// assert
+			assert.NoError(t, err)
+			assert.NoError(t, revErr)
+			assert.Equal(t, tc.expected, output.String())
+			assert.Equal(t, value, back.String())
+		})
+	}
+
+	t.Run("convert base64url with explicit format", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagFrom, cmd.FormatBase64URL)
+
+		// act
+		err := sut.RunE(sut, []string{"BbFqAUbGVt29bsbftKFCeg"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, value, output.String())
+	})
+
+	t.Run("convert values from stdin", func(t *testing.T) {
+		// arrange
+		var (
+			input  = strings.NewReader(value + "\nhmtWcLBWDqJdAF5upbo6Z\n")
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetIn(input)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagTo, cmd.FormatHex)
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "05b16a0146c656ddbd6ec6dfb4a1427a\n05b16a0146c656ddbd6ec6dfb4a1427a", output.String())
+	})
+
+	t.Run("convert bytes from stdin", func(t *testing.T) {
+		// arrange
+		var (
+			raw    = uuid.FromStringOrNil(value).Bytes()
+			input  = bytes.NewReader(append(raw, raw...))
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetIn(input)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagFrom, "bytes")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, value+"\n"+value, output.String())
+	})
+
+	t.Run("return error on truncated bytes", func(t *testing.T) {
+		// arrange
+		var (
+			input  = bytes.NewReader([]byte{0x01, 0x02})
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetIn(input)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagFrom, "bytes")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error on ambiguous value", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"2ABCDEFGH2ABCDEFGH2ABCDEFA"})
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("detect crockford value ending in a character base32 cannot end in", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"2ABCDEFGH2ABCDEFGH2ABCDEF7"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "4a5b1ae7-c222-52d8-d73e-111296c6b9e7", output.String())
+	})
+
+	t.Run("convert ULID to UUID and back", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			back   = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+			revSut = cmd.ConvertCmd()
+		)
+		sut.SetOut(output)
+		revSut.SetOut(back)
+		_ = sut.Flags().Set(cmd.FlagFrom, "ulid")
+		_ = revSut.Flags().Set(cmd.FlagTo, "ulid")
+
+		// act
+		err := sut.RunE(sut, []string{"01ARZ3NDEKTSV4RRFFQ69G5FAV"})
+		revErr := revSut.RunE(revSut, []string{output.String()})
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, revErr)
+		assert.Equal(t, "01563e3a-b5d3-d676-4c61-efb99302bd5b", output.String())
+		assert.Equal(t, "01ARZ3NDEKTSV4RRFFQ69G5FAV", back.String())
+	})
+
+	t.Run("return error on integer exceeding 128 bits", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagFrom, cmd.FormatInteger)
+
+		// act
+		err := sut.RunE(sut, []string{"340282366920938463463374607431768211456"})
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("convert V1 to V6 and back", func(t *testing.T) {
+		const (
+			v1 = "10306965-cad5-11f1-8583-fec08b654598"
+			v6 = "1f1cad51-0306-6965-8583-fec08b654598"
+		)
+
+		for input, version := range map[string]string{v1: "6", v6: "1"} {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				sut    = cmd.ConvertCmd()
+			)
+			sut.SetOut(output)
+			_ = sut.Flags().Set(cmd.FlagToVersion, version)
+
+			// act
+			err := sut.RunE(sut, []string{input})
+
+			// assert
+			assert.NoError(t, err)
+
+			expected := v6
+			if version == "1" {
+				expected = v1
+			}
+			assert.Equal(t, expected, output.String())
+		}
+
+		// the timestamp, clock sequence and node are kept
+		ts1, _ := uuid.TimestampFromV1(uuid.FromStringOrNil(v1))
+		ts6, _ := uuid.TimestampFromV6(uuid.FromStringOrNil(v6))
+		assert.Equal(t, ts1, ts6)
+		assert.Equal(t, v1[19:], v6[19:])
+	})
+
+	t.Run("keep values of the target version", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagToVersion, "6")
+
+		// act
+		err := sut.RunE(sut, []string{"1f1cad51-0306-6965-8583-fec08b654598"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "1f1cad51-0306-6965-8583-fec08b654598", output.String())
+	})
+
+	t.Run("return error on converting other versions", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagToVersion, "6")
+
+		// act
+		err := sut.RunE(sut, []string{value})
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error on unsupported target version", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ConvertCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagToVersion, "7")
+
+		// act
+		err := sut.RunE(sut, []string{"10306965-cad5-11f1-8583-fec08b654598"})
+
+		// assert
+		assert.Error(t, err)
+	})
+}
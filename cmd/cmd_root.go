@@ -2,38 +2,23 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
-	"github.com/gofrs/uuid/v5"
 	"github.com/spf13/cobra"
 )
 
-func RootCmd(defaultUUIDFn func() (uuid.UUID, error)) *cobra.Command {
-	var (
-		applyFlags = MergeAppliers(
-			ApplyNumberFlag(),
-		)
-	)
+// RootCmd returns the root command, which generates values like the default
+// command and accepts the same arguments, flags and settings.
+func RootCmd(defaultCmd *cobra.Command) *cobra.Command {
 	cmd := &cobra.Command{
-		Use:   "uuid",
-		Short: fmt.Sprintf("CLI for generating UUIDs (default V%d)", uuid.Must(defaultUUIDFn()).Version()),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			number, err := cmd.Flags().GetUint32(FlagNumber)
-			if err != nil {
-				return err
-			}
-
-			return writeMany(int(number), cmd.OutOrStdout(), func() (string, error) {
-				value, genErr := defaultUUIDFn()
-				if genErr != nil {
-					return "", fmt.Errorf("generating UUID: %w", genErr)
-				}
-
-				return value.String(), nil
-			})
-		},
+		Use:         "uuid",
+		Short:       fmt.Sprintf("CLI for generating UUIDs (default %s)", strings.ToUpper(defaultCmd.Name())),
+		Args:        defaultCmd.Args,
+		RunE:        defaultCmd.RunE,
+		Annotations: map[string]string{annotationSettings: defaultCmd.Name()},
 	}
 
-	applyFlags(cmd)
+	cmd.Flags().AddFlagSet(defaultCmd.Flags())
 
 	return cmd
 }
@@ -0,0 +1,197 @@
+package cmd_test
+
+import (
+	"bytes"
+	"crypto/rand"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/cmd"
+	"github.com/legaard/uuidy/generate"
+	"github.com/legaard/uuidy/internal/assert"
+	"github.com/spf13/cobra"
+)
+
+func TestRootCmd(t *testing.T) {
+	t.Run("short reflects default command", func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.RootCmd(cmd.V7Cmd(rand.Reader))
+		)
+
+		// act
+		actual := sut.Short
+
+		// assert
+		assert.Equal(t, "CLI for generating UUIDs (default V7)", actual)
+	})
+
+	t.Run("generate UUID with default command", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.RootCmd(cmd.V7Cmd(rand.Reader))
+		)
+		sut.SetOut(writerMock)
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+		actual := writerMock.WriteCalls()[0].P
+		assert.UUIDVersion(t, 7, string(actual))
+	})
+
+	t.Run("generate UUID with flags and args of default command", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.RootCmd(cmd.V5Cmd(uuid.NamespaceDNS, nil))
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagNamespace, "url")
+
+		// act
+		err := sut.RunE(sut, []string{"testing"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+		actual := writerMock.WriteCalls()[0].P
+		assert.Equal(t, uuid.NewV5(uuid.NamespaceURL, "testing").String(), string(actual))
+	})
+
+	t.Run("generate UUIDs with settings of default command", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings(nil, func(key string) (string, bool) {
+			value, ok := map[string]string{"UUIDY_V7_NUMBER": "3"}[key]
+			return value, ok
+		})
+		assert.NoError(t, err)
+
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.RootCmd(cmd.V7Cmd(rand.Reader))
+		)
+		sut.SetOut(output)
+		sut.SetArgs([]string{})
+		cmd.ApplySettings(settings)(sut)
+
+		// act
+		err = sut.Execute()
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 3, len(strings.Split(output.String(), "\n")))
+	})
+}
+
+func TestRegistry(t *testing.T) {
+	for name, expected := range map[string]string{
+		"7":      "v7",
+		"v7":     "v7",
+		" V5 ":   "v5",
+		"ULID":   "ulid",
+		"typeid": "typeid",
+	} {
+		t.Run("look up version "+name, func(t *testing.T) {
+			// arrange
+			var sut = cmd.NewRegistry(rand.Reader, uuid.NamespaceDNS, nil)
+
+			// act
+			actual, ok := sut.Lookup(name)
+
+			// assert
+			assert.Equal(t, true, ok)
+			assert.Equal(t, expected, actual.Name)
+		})
+	}
+
+	t.Run("return false for unknown version", func(t *testing.T) {
+		// arrange
+		var sut = cmd.NewRegistry(rand.Reader, uuid.NamespaceDNS, nil)
+
+		// act
+		_, ok := sut.Lookup("9")
+
+		// assert
+		assert.Equal(t, false, ok)
+	})
+
+	t.Run("generate values of registered version", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.NewRegistry(rand.Reader, uuid.NamespaceDNS, nil)
+		)
+		err := sut.Register(cmd.Version{
+			Name:  "zero",
+			Short: "Generate nil UUID",
+			Generate: func(*cobra.Command, io.Reader) (generate.Generator, error) {
+				return generate.GeneratorFunc(func() (uuid.UUID, error) {
+					return uuid.Nil, nil
+				}), nil
+			},
+		})
+		version, _ := sut.Lookup("zero")
+		command := sut.Command(version)
+		command.SetOut(output)
+		_ = command.Flags().Set(cmd.FlagNumber, "2")
+		_ = command.Flags().Set(cmd.FlagFormat, cmd.FormatHex)
+
+		// act
+		runErr := command.RunE(command, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, runErr)
+		assert.Equal(t, "00000000000000000000000000000000\n00000000000000000000000000000000", output.String())
+		assert.Equal(t, "zero", sut.Names()[len(sut.Names())-1])
+	})
+
+	t.Run("derive values of registered version", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.NewRegistry(rand.Reader, uuid.NamespaceDNS, nil)
+		)
+		err := sut.Register(cmd.Version{
+			Name:   "namespace",
+			Derive: func(ns uuid.UUID, _ string) uuid.UUID { return ns },
+		})
+		version, _ := sut.Lookup("namespace")
+		command := sut.Command(version)
+		command.SetOut(output)
+
+		// act
+		runErr := command.RunE(command, []string{"ignored"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, runErr)
+		assert.Equal(t, uuid.NamespaceDNS.String(), output.String())
+	})
+
+	for name, version := range map[string]cmd.Version{
+		"duplicate name":          {Name: "v4", Derive: uuid.NewV5},
+		"missing name":            {Derive: uuid.NewV5},
+		"missing generation func": {Name: "none"},
+	} {
+		t.Run("return error for "+name, func(t *testing.T) {
+			// arrange
+			var sut = cmd.NewRegistry(rand.Reader, uuid.NamespaceDNS, nil)
+
+			// act
+			err := sut.Register(version)
+
+			// assert
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+func ServeCmd(registry *Registry) *cobra.Command {
+	var (
+		applyFlags = MergeAppliers(
+			ApplyListenFlag(),
+			ApplySocketFlag(),
+			ApplyShutdownTimeoutFlag(),
+			ApplyMaxNumberFlag(),
+		)
+		cmd = &cobra.Command{
+			Use:   "serve",
+			Short: "Serve UUIDs over HTTP",
+			Long: "Serves the generators over HTTP, e.g. as a local ID service for integration tests:\n" +
+				serveRoutes(registry) +
+				"Values are encoded in the format of ?format= (default the format of the command, e.g. canonical, or\n" +
+				"crockford for ULIDs). Responses are text, one value per line, unless ?output=json is given or the Accept\n" +
+				"header asks for application/json. Namespaces are resolved like --namespace of v3 and v5, including the\n" +
+				"named namespaces of the config file. The generators, default namespaces and formats are configured by\n" +
+				"the settings of their commands in the config files and environment, e.g. flags.v7.method or\n" +
+				"UUIDY_V5_NAMESPACE.\n\n" +
+				"The server shuts down gracefully on SIGINT or SIGTERM, waiting for requests in flight to complete.",
+			Example: "uuid serve\n" +
+				"uuid serve --listen :8080\n" +
+				"uuid serve --socket /tmp/uuidy.sock",
+			Args: cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				address, err := cmd.Flags().GetString(FlagListen)
+				if err != nil {
+					return err
+				}
+
+				socket, err := cmd.Flags().GetString(FlagSocket)
+				if err != nil {
+					return err
+				}
+
+				timeout, err := cmd.Flags().GetDuration(FlagShutdown)
+				if err != nil {
+					return err
+				}
+
+				maxNumber, err := cmd.Flags().GetUint64(FlagMaxNumber)
+				if err != nil {
+					return err
+				}
+
+				srv, err := newServer(registry, cmd, maxNumber)
+				if err != nil {
+					return err
+				}
+
+				var listener net.Listener
+				if socket != "" {
+					listener, err = net.Listen("unix", socket)
+				} else {
+					listener, err = net.Listen("tcp", address)
+				}
+				if err != nil {
+					return err
+				}
+
+				cmd.SilenceUsage = true
+
+				ctx := cmd.Context()
+				if ctx == nil {
+					ctx = context.Background()
+				}
+
+				ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+				defer stop()
+
+				var (
+					httpServer = &http.Server{
+						Handler:           srv.handler(),
+						ReadHeaderTimeout: 10 * time.Second,
+					}
+					served = make(chan error, 1)
+				)
+
+				go func() {
+					served <- httpServer.Serve(listener)
+				}()
+
+				fmt.Fprintf(cmd.ErrOrStderr(), "listening on %s\n", listener.Addr())
+
+				select {
+				case err = <-served:
+					return err
+				case <-ctx.Done():
+				}
+
+				fmt.Fprintln(cmd.ErrOrStderr(), "shutting down")
+
+				shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+				defer cancel()
+
+				if err = httpServer.Shutdown(shutdownCtx); err != nil {
+					return fmt.Errorf("shutting down: %w", err)
+				}
+
+				if err = <-served; !errors.Is(err, http.ErrServerClosed) {
+					return err
+				}
+
+				return nil
+			},
+		}
+	)
+
+	applyFlags(cmd)
+
+	return cmd
+}
+
+// serveRoutes returns the help of the routes of the server, with the routes
+// of the served versions of the registry.
+func serveRoutes(registry *Registry) string {
+	var generated, derived []string
+	for _, version := range registry.Versions() {
+		switch {
+		case !version.Serve:
+		case version.Derive != nil:
+			derived = append(derived, "/"+version.Name)
+		default:
+			generated = append(generated, "/"+version.Name)
+		}
+	}
+
+	var routes [][2]string
+	if len(generated) > 0 {
+		routes = append(routes, [2]string{"GET " + strings.Join(generated, ", "), "generate n values (?n=10, default 1)"})
+	}
+	if len(derived) > 0 {
+		routes = append(routes, [2]string{"GET " + strings.Join(derived, ", "), "derive a value for each name (?ns=dns:example.com&name=a&name=b)"})
+	}
+	routes = append(routes,
+		[2]string{"GET /parse/{id}", "parse the value (?from=hex for another format)"},
+		[2]string{"GET /metrics", "request metrics in the Prometheus text format"},
+		[2]string{"GET /healthz", "health check"},
+	)
+
+	var width int
+	for _, route := range routes {
+		width = max(width, len(route[0]))
+	}
+
+	var sb strings.Builder
+	for _, route := range routes {
+		fmt.Fprintf(&sb, "  %-*s  %s\n", width, route[0], route[1])
+	}
+	sb.WriteString("\n")
+
+	return sb.String()
+}
@@ -0,0 +1,334 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/cmd"
+	"github.com/legaard/uuidy/generate"
+	"github.com/legaard/uuidy/internal/assert"
+	"github.com/spf13/cobra"
+)
+
+// startServer runs the serve command on a unix socket until the test ends,
+// returning a client sending its requests to the socket.
+func startServer(t *testing.T) *http.Client {
+	t.Helper()
+
+	return runServer(t, cmd.ServeCmd(cmd.NewRegistry(rand.Reader, uuid.NamespaceDNS, map[string]string{"acme": "dns:acme.com"})))
+}
+
+// runServer runs the serve command sut on a unix socket until the test ends,
+// returning a client sending its requests to the socket.
+func runServer(t *testing.T, sut *cobra.Command) *http.Client {
+	t.Helper()
+
+	var (
+		socket      = filepath.Join(t.TempDir(), "uuidy.sock")
+		ctx, cancel = context.WithCancel(context.Background())
+		done        = make(chan error, 1)
+	)
+	sut.SetContext(ctx)
+	sut.SetErr(&bytes.Buffer{})
+	_ = sut.Flags().Set(cmd.FlagSocket, socket)
+	_ = sut.Flags().Set(cmd.FlagMaxNumber, "10")
+
+	go func() {
+		done <- sut.RunE(sut, nil)
+	}()
+
+	for deadline := time.Now().Add(5 * time.Second); ; {
+		if _, err := os.Stat(socket); err == nil {
+			break
+		}
+
+		if time.Now().After(deadline) {
+			t.Fatal("server did not start")
+		}
+
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	t.Cleanup(func() {
+		cancel()
+		assert.NoError(t, <-done)
+	})
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return (&net.Dialer{}).DialContext(ctx, "unix", socket)
+			},
+		},
+	}
+}
+
+func get(t *testing.T, client *http.Client, path string, header http.Header) (int, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, "http://uuidy"+path, nil)
+	assert.NoError(t, err)
+	for key, values := range header {
+		req.Header[key] = values
+	}
+
+	res, err := client.Do(req)
+	assert.NoError(t, err)
+	defer res.Body.Close()
+
+	body, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+
+	return res.StatusCode, string(body)
+}
+
+func TestServeCmd(t *testing.T) {
+	t.Run(`use is "serve"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.ServeCmd(cmd.NewRegistry(nil, uuid.NamespaceDNS, nil))
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "serve", actual)
+	})
+
+	t.Run("generate UUIDs as text", func(t *testing.T) {
+		// arrange
+		var client = startServer(t)
+
+		// act
+		code, body := get(t, client, "/v4?n=3", nil)
+
+		// assert
+		assert.Equal(t, http.StatusOK, code)
+
+		lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+		assert.Equal(t, 3, len(lines))
+		for _, line := range lines {
+			assert.Equal(t, uuid.V4, uuid.FromStringOrNil(line).Version())
+		}
+	})
+
+	t.Run("generate UUIDs with settings of the version", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings(nil, func(key string) (string, bool) {
+			value, ok := map[string]string{"UUIDY_V7_EPOCH": "2025-01-18T12:27:25.397Z"}[key]
+			return value, ok
+		})
+		assert.NoError(t, err)
+
+		var (
+			registry = cmd.NewRegistry(rand.Reader, uuid.NamespaceDNS, nil)
+			sut      = cmd.ServeCmd(registry)
+			root     = cmd.RootCmd(cmd.V4Cmd(rand.Reader))
+		)
+		root.AddCommand(sut)
+		root.AddCommand(registry.Commands()...)
+		cmd.ApplySettings(settings)(root)
+
+		var client = runServer(t, sut)
+
+		// act
+		code, body := get(t, client, "/v7", nil)
+
+		// assert
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, true, strings.HasPrefix(body, "01947961-e155-7"))
+	})
+
+	t.Run("derive UUIDs with namespace and format of the version", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings(nil, func(key string) (string, bool) {
+			value, ok := map[string]string{"UUIDY_NAMESPACE": "acme", "UUIDY_V5_FORMAT": "upper"}[key]
+			return value, ok
+		})
+		assert.NoError(t, err)
+
+		var (
+			namespaces = map[string]string{"acme": "dns:acme.com"}
+			registry   = cmd.NewRegistry(rand.Reader, uuid.NamespaceDNS, namespaces)
+			sut        = cmd.ServeCmd(registry)
+			root       = cmd.RootCmd(cmd.V4Cmd(rand.Reader))
+			ns, _      = generate.ResolveNamespace("acme", namespaces)
+		)
+		root.AddCommand(sut)
+		root.AddCommand(registry.Commands()...)
+		cmd.ApplySettings(settings)(root)
+
+		var client = runServer(t, sut)
+
+		// act
+		code, body := get(t, client, "/v5?name=x", nil)
+
+		// assert
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, strings.ToUpper(uuid.NewV5(ns, "x").String())+"\n", body)
+	})
+
+	t.Run("generate TypeIDs with prefix of the version", func(t *testing.T) {
+		// arrange
+		settings, err := cmd.LoadSettings(nil, func(key string) (string, bool) {
+			value, ok := map[string]string{"UUIDY_TYPEID_PREFIX": "user"}[key]
+			return value, ok
+		})
+		assert.NoError(t, err)
+
+		var (
+			registry = cmd.NewRegistry(rand.Reader, uuid.NamespaceDNS, nil)
+			sut      = cmd.ServeCmd(registry)
+			root     = cmd.RootCmd(cmd.V4Cmd(rand.Reader))
+		)
+		root.AddCommand(sut)
+		root.AddCommand(registry.Commands()...)
+		cmd.ApplySettings(settings)(root)
+
+		var client = runServer(t, sut)
+
+		// act
+		code, body := get(t, client, "/typeid?n=2", nil)
+
+		// assert
+		assert.Equal(t, http.StatusOK, code)
+
+		lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+		assert.Equal(t, 2, len(lines))
+		for _, line := range lines {
+			assert.Equal(t, true, strings.HasPrefix(line, "user_"))
+			assert.Equal(t, len("user_")+26, len(line))
+		}
+	})
+
+	t.Run("generate UUIDs as JSON", func(t *testing.T) {
+		// arrange
+		var client = startServer(t)
+
+		// act
+		code, body := get(t, client, "/v7?n=2", http.Header{"Accept": {"application/json"}})
+
+		// assert
+		assert.Equal(t, http.StatusOK, code)
+
+		var actual struct {
+			UUIDs []string `json:"uuids"`
+		}
+		assert.NoError(t, json.Unmarshal([]byte(body), &actual))
+		assert.Equal(t, 2, len(actual.UUIDs))
+		assert.Equal(t, true, actual.UUIDs[0] < actual.UUIDs[1])
+	})
+
+	t.Run("generate ULIDs in crockford format", func(t *testing.T) {
+		// arrange
+		var client = startServer(t)
+
+		// act
+		code, body := get(t, client, "/ulid?n=2", nil)
+
+		// assert
+		assert.Equal(t, http.StatusOK, code)
+
+		lines := strings.Split(strings.TrimSuffix(body, "\n"), "\n")
+		assert.Equal(t, 2, len(lines))
+		for _, line := range lines {
+			assert.Equal(t, 26, len(line))
+		}
+	})
+
+	t.Run("help lists routes of served versions", func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.ServeCmd(cmd.NewRegistry(nil, uuid.NamespaceDNS, nil))
+		)
+
+		// act
+		actual := sut.Long
+
+		// assert
+		assert.Equal(t, true, strings.Contains(actual, "GET /v1, /v4, /v6, /v7, /ulid, /typeid  generate n values"))
+		assert.Equal(t, true, strings.Contains(actual, "GET /v3, /v5                            derive a value for each name"))
+	})
+
+	t.Run("derive UUIDs from named namespace", func(t *testing.T) {
+		// arrange
+		var client = startServer(t)
+
+		// act
+		code, body := get(t, client, "/v5?ns=acme&name=a&name=b&format=hex", nil)
+
+		// assert
+		assert.Equal(t, http.StatusOK, code)
+
+		var ns = uuid.NewV5(uuid.NamespaceDNS, "acme.com")
+		assert.Equal(t, hex.EncodeToString(uuid.NewV5(ns, "a").Bytes())+"\n"+hex.EncodeToString(uuid.NewV5(ns, "b").Bytes())+"\n", body)
+	})
+
+	t.Run("parse UUID", func(t *testing.T) {
+		// arrange
+		var client = startServer(t)
+
+		// act
+		code, body := get(t, client, "/parse/01947961-e155-7a32-82f1-1b2491f301ac?output=json", nil)
+
+		// assert
+		assert.Equal(t, http.StatusOK, code)
+
+		var actual map[string]any
+		assert.NoError(t, json.Unmarshal([]byte(body), &actual))
+		assert.Equal[any](t, "2025-01-18T12:27:25.397Z", actual["time"])
+	})
+
+	for path, expected := range map[string]int{
+		"/v4?n=11":           http.StatusBadRequest,
+		"/v4?n=-1":           http.StatusBadRequest,
+		"/v4?format=binary":  http.StatusBadRequest,
+		"/v4?output=xml":     http.StatusBadRequest,
+		"/v5":                http.StatusBadRequest,
+		"/v5?ns=nope&name=a": http.StatusBadRequest,
+		"/parse/invalid":     http.StatusBadRequest,
+		"/v2":                http.StatusNotFound,
+		"/v8":                http.StatusNotFound,
+	} {
+		t.Run("return error on "+path, func(t *testing.T) {
+			// arrange
+			var client = startServer(t)
+
+			// act
+			code, body := get(t, client, path, nil)
+
+			// assert
+			assert.Equal(t, expected, code)
+			assert.Equal(t, true, strings.HasPrefix(body, "error: "))
+		})
+	}
+
+	t.Run("report request metrics", func(t *testing.T) {
+		// arrange
+		var client = startServer(t)
+		_, _ = get(t, client, "/v4?n=2", nil)
+		_, _ = get(t, client, "/v4?n=11", nil)
+
+		// act
+		code, body := get(t, client, "/metrics", nil)
+
+		// assert
+		assert.Equal(t, http.StatusOK, code)
+		assert.Equal(t, true, strings.Contains(body, "uuidy_http_requests_total{route=\"/v4\",code=\"200\"} 1\n"))
+		assert.Equal(t, true, strings.Contains(body, "uuidy_http_requests_total{route=\"/v4\",code=\"400\"} 1\n"))
+		assert.Equal(t, true, strings.Contains(body, "uuidy_generated_total{version=\"4\"} 2\n"))
+	})
+}
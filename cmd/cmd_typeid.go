@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/spf13/cobra"
+)
+
+var typeIDVersion = Version{
+	Name:   "typeid",
+	Short:  "Generate TypeID",
+	Type:   "TypeID",
+	Format: FormatTypeID,
+	Long: "Generates TypeIDs: a type prefix and a V7 UUID in lowercase Crockford base32, separated by an\n" +
+		"underscore, e.g. user_01jhwp3ranf8s85w8v4j8z60dc. The UUIDs are generated like those of uuid v7,\n" +
+		"so the V7 flags apply. Given UUIDs, they are encoded as TypeIDs instead.\n\n" +
+		"The prefix is at most 63 lowercase letters and underscores, starting and ending with a letter.\n" +
+		"Without a prefix, a TypeID is the encoded UUID alone. TypeIDs are decoded with uuid parse.",
+	Example: "uuid typeid --prefix user\n" +
+		"uuid typeid --prefix user -n 10\n" +
+		"uuid typeid --prefix user 01947961-e155-7a32-82f1-1b2491f301ac",
+	Flags: MergeAppliers(
+		ApplyPrefixFlag(),
+		ApplyEpocTime(),
+		ApplyTimezoneFlag(),
+		ApplySeedFlag(),
+		ApplyStreamFlag(),
+		ApplyRateFlag(),
+		ApplyDurationFlag(),
+		ApplyMethodFlag(),
+		ApplyCounterBitsFlag(),
+	),
+	Generate:   v7GeneratorOf,
+	EncodeArgs: true,
+	Serve:      true,
+}
+
+func TypeIDCmd(random io.Reader) *cobra.Command {
+	return (&Registry{random: random}).Command(typeIDVersion)
+}
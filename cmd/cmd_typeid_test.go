@@ -0,0 +1,112 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/legaard/uuidy/cmd"
+	"github.com/legaard/uuidy/internal/assert"
+)
+
+func TestTypeIDCmd(t *testing.T) {
+	t.Run(`use is "typeid [uuid...]"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.TypeIDCmd(nil)
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "typeid [uuid...]", actual)
+	})
+
+	t.Run("encode UUIDs as TypeIDs", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.TypeIDCmd(nil)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagPrefix, "user")
+
+		// act
+		err := sut.RunE(sut, []string{"01890a5d-ac96-774b-bcce-b302099a8057", "00000000-0000-0000-0000-000000000000"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "user_01h455vb4pex5vsknk084sn02q\nuser_00000000000000000000000000", output.String())
+	})
+
+	t.Run("encode UUID without prefix", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.TypeIDCmd(nil)
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"01890a5d-ac96-774b-bcce-b302099a8057"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "01h455vb4pex5vsknk084sn02q", output.String())
+	})
+
+	t.Run("generate sorted TypeIDs of V7 UUIDs", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.TypeIDCmd(nil)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagPrefix, "order_item")
+		_ = sut.Flags().Set(cmd.FlagNumber, "100")
+		_ = sut.Flags().Set(cmd.FlagEpoch, "2025-01-18T12:27:25.397Z")
+		_ = sut.Flags().Set(cmd.FlagSeed, "typeid")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+
+		values := strings.Split(output.String(), "\n")
+		assert.Equal(t, 100, len(values))
+		assert.Equal(t, true, slices.IsSorted(values))
+		assert.Equal(t, len(values), len(slices.Compact(values)))
+		for _, value := range values {
+			assert.Equal(t, true, strings.HasPrefix(value, "order_item_01jhwp3ran"))
+		}
+	})
+
+	for _, prefix := range []string{
+		"User",
+		"user-id",
+		"_user",
+		"user_",
+		"user1",
+		strings.Repeat("a", 64),
+	} {
+		t.Run(fmt.Sprintf("return error on invalid prefix %q", prefix), func(t *testing.T) {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				sut    = cmd.TypeIDCmd(nil)
+			)
+			sut.SetOut(output)
+			_ = sut.Flags().Set(cmd.FlagPrefix, prefix)
+
+			// act
+			err := sut.RunE(sut, []string{"01890a5d-ac96-774b-bcce-b302099a8057"})
+
+			// assert
+			assert.Error(t, err)
+		})
+	}
+}
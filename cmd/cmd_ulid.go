@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"io"
+
+	"github.com/legaard/uuidy/generate"
+	"github.com/spf13/cobra"
+)
+
+var ulidVersion = Version{
+	Name:   "ulid",
+	Short:  "Generate ULID",
+	Type:   "ULID",
+	Format: FormatCrockford,
+	Long: "Lexicographically sortable identifier of a 48 bit unix timestamp in milliseconds and 80 random\n" +
+		"bits, encoded in Crockford base32. ULIDs have the same size as UUIDs and can be converted with\n" +
+		"uuid convert --from ulid or --to ulid.\n\n" +
+		"With --monotonic, values generated within the same millisecond are kept sorted by incrementing\n" +
+		"the random bits of the previous value. An error is returned when they overflow.",
+	Example: "uuid ulid\n" +
+		"uuid ulid -n 1000 --monotonic\n" +
+		"uuid ulid | uuid convert --from ulid --to canonical",
+	Flags: MergeAppliers(
+		ApplyEpocTime(),
+		ApplyTimezoneFlag(),
+		ApplySeedFlag(),
+		ApplyMonotonicFlag(),
+		ApplyStreamFlag(),
+		ApplyRateFlag(),
+		ApplyDurationFlag(),
+	),
+	Generate: func(cmd *cobra.Command, random io.Reader) (generate.Generator, error) {
+		monotonic, err := cmd.Flags().GetBool(FlagMonotonic)
+		if err != nil {
+			return nil, err
+		}
+
+		opts, err := optionsOf(cmd, random)
+		if err != nil {
+			return nil, err
+		}
+
+		return generate.NewULID(generate.ULIDOptions{Options: opts, Monotonic: monotonic}), nil
+	},
+	Serve: true,
+}
+
+func ULIDCmd(random io.Reader) *cobra.Command {
+	return (&Registry{random: random}).Command(ulidVersion)
+}
@@ -0,0 +1,107 @@
+package cmd_test
+
+import (
+	"bytes"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/legaard/uuidy/cmd"
+	"github.com/legaard/uuidy/internal/assert"
+)
+
+func TestULIDCmd(t *testing.T) {
+	t.Run(`use is "ulid"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.ULIDCmd(nil)
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "ulid", actual)
+	})
+
+	t.Run("generate ULID from random source", func(t *testing.T) {
+		// arrange
+		var (
+			random = bytes.NewReader(bytes.Repeat([]byte{0x42}, 10))
+			output = &bytes.Buffer{}
+			sut    = cmd.ULIDCmd(random)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagEpoch, "2025-01-18T12:27:25.397Z")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "01JHWP3RAN89144GJ289144GJ2", output.String())
+	})
+
+	t.Run("generate sorted ULIDs in monotonic mode", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ULIDCmd(nil)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagNumber, "100")
+		_ = sut.Flags().Set(cmd.FlagEpoch, "2025-01-18T12:27:25.397Z")
+		_ = sut.Flags().Set(cmd.FlagSeed, "ulid")
+		_ = sut.Flags().Set(cmd.FlagMonotonic, "true")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+
+		values := strings.Split(output.String(), "\n")
+		assert.Equal(t, 100, len(values))
+		assert.Equal(t, true, slices.IsSorted(values))
+		assert.Equal(t, len(values), len(slices.Compact(values)))
+		for _, value := range values {
+			assert.Equal(t, true, strings.HasPrefix(value, "01JHWP3RAN"))
+		}
+	})
+
+	t.Run("return error on overflow in monotonic mode", func(t *testing.T) {
+		// arrange
+		var (
+			random = bytes.NewReader(bytes.Repeat([]byte{0xff}, 10))
+			output = &bytes.Buffer{}
+			sut    = cmd.ULIDCmd(random)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagNumber, "2")
+		_ = sut.Flags().Set(cmd.FlagEpoch, "2025-01-18T12:27:25.397Z")
+		_ = sut.Flags().Set(cmd.FlagMonotonic, "true")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error on time before the unix epoch", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ULIDCmd(nil)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagEpoch, "1969-12-31")
+		_ = sut.Flags().Set(cmd.FlagSeed, "ulid")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
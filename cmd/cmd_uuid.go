@@ -1,47 +1,41 @@
 package cmd
 
 import (
+	"bufio"
+	"encoding/csv"
 	"fmt"
 	"io"
-	"time"
+	"math/big"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/codec"
+	"github.com/legaard/uuidy/generate"
+	"github.com/legaard/uuidy/inspect"
 	"github.com/spf13/cobra"
 )
 
 func NullCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:   "null",
-		Short: "Output null UUID",
-		Run: func(cmd *cobra.Command, _ []string) {
-			cmd.Printf("00000000-0000-0000-0000-000000000000")
-		},
-	}
-}
-
-func V1Cmd() *cobra.Command {
 	var (
 		applyFlags = MergeAppliers(
-			ApplyNumberFlag(),
+			ApplyFormatFlag(),
+			ApplyByteOrderFlag(FlagByteOrder),
 		)
 		cmd = &cobra.Command{
-			Use:     "v1",
-			Short:   "Generate UUID V1",
-			Long:    "UUID based on the current timestamp and MAC address",
-			Example: "uuid v1",
+			Use:   "null",
+			Short: "Output null UUID",
 			RunE: func(cmd *cobra.Command, _ []string) error {
-				number, err := cmd.Flags().GetUint32(FlagNumber)
+				format, err := cmd.Flags().GetString(FlagFormat)
 				if err != nil {
 					return err
 				}
 
-				return writeMany(int(number), cmd.OutOrStdout(), func() (string, error) {
-					value, genErr := uuid.NewV1()
-					if genErr != nil {
-						return "", fmt.Errorf("generating UUID: %w", genErr)
-					}
+				order, err := cmd.Flags().GetString(FlagByteOrder)
+				if err != nil {
+					return err
+				}
 
-					return value.String(), nil
+				return writeMany(1, cmd.OutOrStdout(), codec.Encoding{Format: format, Order: order}, func() (uuid.UUID, error) {
+					return uuid.Nil, nil
 				})
 			},
 		}
@@ -52,36 +46,28 @@ func V1Cmd() *cobra.Command {
 	return cmd
 }
 
-func V3Cmd(defaultNamespace uuid.UUID) *cobra.Command {
+func MaxCmd() *cobra.Command {
 	var (
 		applyFlags = MergeAppliers(
-			ApplyNumberFlag(),
-			ApplyNamespaceFlag(defaultNamespace.String()),
+			ApplyFormatFlag(),
+			ApplyByteOrderFlag(FlagByteOrder),
 		)
 		cmd = &cobra.Command{
-			Use:     "v3 [value]",
-			Short:   "Generate UUID V3",
-			Long:    "UUID based on the MD5 hash of the namespace UUID and name",
-			Example: `uuid v3 "Hello v3"`,
-			Args:    cobra.ExactArgs(1),
-			RunE: func(cmd *cobra.Command, args []string) error {
-				number, err := cmd.Flags().GetUint32(FlagNumber)
+			Use:   "max",
+			Short: "Output max UUID",
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				format, err := cmd.Flags().GetString(FlagFormat)
 				if err != nil {
 					return err
 				}
 
-				namespace, err := cmd.Flags().GetString(FlagNamespace)
+				order, err := cmd.Flags().GetString(FlagByteOrder)
 				if err != nil {
 					return err
 				}
 
-				ns, err := uuid.FromString(namespace)
-				if err != nil {
-					return fmt.Errorf("invalid namespace: %w", err)
-				}
-
-				return writeMany(int(number), cmd.OutOrStdout(), func() (string, error) {
-					return uuid.NewV3(ns, args[0]).String(), nil
+				return writeMany(1, cmd.OutOrStdout(), codec.Encoding{Format: format, Order: order}, func() (uuid.UUID, error) {
+					return uuid.Max, nil
 				})
 			},
 		}
@@ -92,147 +78,332 @@ func V3Cmd(defaultNamespace uuid.UUID) *cobra.Command {
 	return cmd
 }
 
-func V4Cmd() *cobra.Command {
-	var (
-		applyFlags = MergeAppliers(
-			ApplyNumberFlag(),
-		)
-		cmd = &cobra.Command{
-			Use:     "v4",
-			Short:   "Generate UUID V4",
-			Long:    "Randomly generated UUID",
-			Example: "uuid v4",
-			RunE: func(cmd *cobra.Command, _ []string) error {
-				number, err := cmd.Flags().GetUint32(FlagNumber)
-				if err != nil {
-					return err
-				}
+var v1Version = Version{
+	Name:    "v1",
+	Short:   "Generate UUID V1",
+	Long:    "UUID based on the current timestamp and MAC address",
+	Example: "uuid v1",
+	Flags: MergeAppliers(
+		ApplyEpocTime(),
+		ApplyTimezoneFlag(),
+		ApplySeedFlag(),
+		ApplyStreamFlag(),
+		ApplyRateFlag(),
+		ApplyDurationFlag(),
+	),
+	Generate: func(cmd *cobra.Command, random io.Reader) (generate.Generator, error) {
+		opts, err := optionsOf(cmd, random)
+		if err != nil {
+			return nil, err
+		}
 
-				return writeMany(int(number), cmd.OutOrStdout(), func() (string, error) {
-					value, genErr := uuid.NewV4()
-					if genErr != nil {
-						return "", fmt.Errorf("generating UUID: %w", genErr)
-					}
+		return generate.NewV1(opts), nil
+	},
+	Serve: true,
+}
 
-					return value.String(), nil
-				})
-			},
+func V1Cmd(random io.Reader) *cobra.Command {
+	return (&Registry{random: random}).Command(v1Version)
+}
+
+var v3Version = Version{
+	Name:  "v3",
+	Short: "Generate UUID V3",
+	Long: "UUID based on the MD5 hash of the namespace UUID and name\n\n" +
+		"The namespace is a UUID, one of the aliases dns, url, oid and x500, or a name from the namespaces of\n" +
+		"the config file, optionally followed by a path of names that are derived from it one by one with V5\n\n" +
+		"Without a value, names are read from stdin (or --file) one per line and a UUID is derived for each,\n" +
+		"ignoring surrounding whitespace and empty lines. Use --output tsv or csv to write name and UUID pairs.",
+	Example: `uuid v3 "Hello v3"` + "\n" +
+		`uuid v3 --namespace dns:example.com/tenants/acme "Hello v3"` + "\n" +
+		"uuid v3 --output tsv < emails.txt",
+	Derive: uuid.NewV3,
+	Serve:  true,
+}
+
+func V3Cmd(defaultNamespace uuid.UUID, namespaces map[string]string) *cobra.Command {
+	return (&Registry{defaultNamespace: defaultNamespace, namespaces: namespaces}).Command(v3Version)
+}
+
+var v4Version = Version{
+	Name:  "v4",
+	Short: "Generate UUID V4",
+	Long:  "Randomly generated UUID",
+	Example: "uuid v4\n" +
+		"uuid v4 --stream | head -n 1000000",
+	Flags: MergeAppliers(
+		ApplySeedFlag(),
+		ApplyStreamFlag(),
+		ApplyRateFlag(),
+		ApplyDurationFlag(),
+	),
+	Generate: func(cmd *cobra.Command, random io.Reader) (generate.Generator, error) {
+		opts, err := optionsOf(cmd, random)
+		if err != nil {
+			return nil, err
 		}
-	)
 
-	applyFlags(cmd)
+		return generate.NewV4(opts), nil
+	},
+	Serve: true,
+}
 
-	return cmd
+func V4Cmd(random io.Reader) *cobra.Command {
+	return (&Registry{random: random}).Command(v4Version)
+}
+
+var v5Version = Version{
+	Name:  "v5",
+	Short: "Generate UUID V5",
+	Long: "UUID based on SHA-1 hash of the namespace UUID and value\n\n" +
+		"The namespace is a UUID, one of the aliases dns, url, oid and x500, or a name from the namespaces of\n" +
+		"the config file, optionally followed by a path of names that are derived from it one by one with V5\n\n" +
+		"Without a value, names are read from stdin (or --file) one per line and a UUID is derived for each,\n" +
+		"ignoring surrounding whitespace and empty lines. Use --output tsv or csv to write name and UUID pairs.",
+	Example: `uuid v5 "Hello v5"` + "\n" +
+		`uuid v5 --namespace dns:example.com/tenants/acme "Hello v5"` + "\n" +
+		"uuid v5 --output tsv < emails.txt",
+	Derive: uuid.NewV5,
+	Serve:  true,
 }
 
-func V5Cmd(defaultNamespace uuid.UUID) *cobra.Command {
+func V5Cmd(defaultNamespace uuid.UUID, namespaces map[string]string) *cobra.Command {
+	return (&Registry{defaultNamespace: defaultNamespace, namespaces: namespaces}).Command(v5Version)
+}
+
+var v6Version = Version{
+	Name:    "v6",
+	Short:   "Generate UUID V6",
+	Long:    "K-sortable UUID based on a timestamp and 48 bits of pseudorandom data",
+	Example: "uuid v6",
+	Flags: MergeAppliers(
+		ApplyEpocTime(),
+		ApplyTimezoneFlag(),
+		ApplySeedFlag(),
+		ApplyStreamFlag(),
+		ApplyRateFlag(),
+		ApplyDurationFlag(),
+	),
+	Generate: func(cmd *cobra.Command, random io.Reader) (generate.Generator, error) {
+		opts, err := optionsOf(cmd, random)
+		if err != nil {
+			return nil, err
+		}
+
+		return generate.NewV6(opts), nil
+	},
+	Serve: true,
+}
+
+func V6Cmd(random io.Reader) *cobra.Command {
+	return (&Registry{random: random}).Command(v6Version)
+}
+
+var v7Version = Version{
+	Name:  "v7",
+	Short: "Generate UUID V7",
+	Long: "K-sortable UUID based on the current millisecond precision. Values are strictly increasing, kept\n" +
+		"monotonic within a millisecond by one of the methods of RFC 9562, section 6.2:\n" +
+		"  counter    a counter of --counter-bits bits in rand_a and the leading bits of rand_b (default)\n" +
+		"  random     the random bits are incremented by a random value\n" +
+		"  precision  rand_a holds the sub-millisecond fraction of the time in 12 bits\n" +
+		"An error is returned when the counter overflows, e.g. when generating more than 4096 values for\n" +
+		"a fixed --epoch with the precision method.",
+	Example: "uuid v7\n" +
+		"uuid v7 -n 1000 --method precision\n" +
+		"uuid v7 --stream --rate 100 --duration 1m",
+	Flags: MergeAppliers(
+		ApplyEpocTime(),
+		ApplyTimezoneFlag(),
+		ApplySeedFlag(),
+		ApplyStreamFlag(),
+		ApplyRateFlag(),
+		ApplyDurationFlag(),
+		ApplyMethodFlag(),
+		ApplyCounterBitsFlag(),
+	),
+	Generate: v7GeneratorOf,
+	Serve:    true,
+}
+
+func V7Cmd(random io.Reader) *cobra.Command {
+	return (&Registry{random: random}).Command(v7Version)
+}
+
+var v8Version = Version{
+	Name:  "v8",
+	Short: "Generate UUID V8",
+	Long: "UUID based on a custom 122 bit payload, given either as hex or as a layout of named bit fields\n" +
+		"filled from values (fields are packed from the most significant bit)",
+	Example: "uuid v8 --data 0x1234abcd\n" +
+		"uuid v8 --layout shard:16,tenant:32 --field shard=3 --field tenant=0x2a",
+	Flags: MergeAppliers(
+		ApplyDataFlag(),
+		ApplyLayoutFlag(),
+		ApplyFieldFlag(),
+	),
+	Generate: func(cmd *cobra.Command, _ io.Reader) (generate.Generator, error) {
+		data, err := cmd.Flags().GetString(FlagData)
+		if err != nil {
+			return nil, err
+		}
+
+		layout, err := cmd.Flags().GetString(FlagLayout)
+		if err != nil {
+			return nil, err
+		}
+
+		fieldValues, err := cmd.Flags().GetStringToString(FlagField)
+		if err != nil {
+			return nil, err
+		}
+
+		var payload *big.Int
+		switch {
+		case data != "" && layout != "":
+			return nil, fmt.Errorf("--%s and --%s cannot be combined", FlagData, FlagLayout)
+		case data != "":
+			payload, err = generate.ParseData(data)
+			if err != nil {
+				return nil, fmt.Errorf("invalid data: %w", err)
+			}
+		case layout != "":
+			fields, layoutErr := generate.ParseLayout(layout)
+			if layoutErr != nil {
+				return nil, fmt.Errorf("invalid layout: %w", layoutErr)
+			}
+
+			payload, err = generate.PackPayload(fields, fieldValues)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, fmt.Errorf("either --%s or --%s is required", FlagData, FlagLayout)
+		}
+
+		value, err := generate.NewV8(payload)
+		if err != nil {
+			return nil, fmt.Errorf("generating UUID: %w", err)
+		}
+
+		// the payload is fixed, so every value is the same
+		return generate.GeneratorFunc(func() (uuid.UUID, error) {
+			return value, nil
+		}), nil
+	},
+}
+
+func V8Cmd() *cobra.Command {
+	return (&Registry{}).Command(v8Version)
+}
+
+func ParseCmd() *cobra.Command {
 	var (
 		applyFlags = MergeAppliers(
-			ApplyNumberFlag(),
-			ApplyNamespaceFlag(defaultNamespace.String()),
+			ApplyLayoutFlag(),
+			ApplyOutputFlag(OutputText, OutputJSON, OutputYAML, OutputEnv),
+			ApplyFileFlag(),
+			ApplyFromFormatFlag(FormatCanonical),
+			ApplyByteOrderFlag(FlagByteOrder),
 		)
 		cmd = &cobra.Command{
-			Use:     "v5 [value]",
-			Short:   "Generate UUID V5",
-			Long:    "UUID based on SHA-1 hash of the namespace UUID and value",
-			Example: `uuid v5 "Hello v5"`,
-			Args:    cobra.ExactArgs(1),
+			Use:   "parse [value]",
+			Short: "Parse UUID value",
+			Long: "Parses UUID value and outputs version details (use --layout to decode the fields of a V8 UUID)\n\n" +
+				"The output is a breakdown of the fields of the UUID: variant (ncs, rfc9562, microsoft or future), time,\n" +
+				"clock sequence and node of V1/V6 (node_random is set when the multicast bit marks the node as random),\n" +
+				"rand_a/rand_b of V7, the random bits of V4, the hash bits of V3/V5 and the payload of V8.\n\n" +
+				"ULIDs are parsed as well, showing the time of their 48 bit timestamp and their 80 random bits, and\n" +
+				"so are TypeIDs, showing the details of the UUID they wrap.\n\n" +
+				"The json, yaml and env outputs always contain the keys uuid, ulid, typeid, special, version, variant,\n" +
+				"time, unix_ms, clock_seq, node, node_random, rand_a, rand_b, random, hash and payload; keys that do\n" +
+				"not apply to the version are null (empty for env). The text output leaves them out.\n\n" +
+				"Without a value, newline-delimited values are read from stdin (or --file) and parsed as a stream.\n" +
+				"Invalid lines and a summary are written to stderr.\n\n" +
+				"Values stored in another format or byte order, e.g. the mixed-endian bytes of a SQL Server\n" +
+				"uniqueidentifier, can be parsed with --from and --byte-order.",
+			Example: "uuid parse 01ebb00e-d38a-11ef-8f83-426648c33d81\n" +
+				"uuid parse -o json 01ebb00e-d38a-11ef-8f83-426648c33d81 | jq .time\n" +
+				"uuid parse 01JHWP3RANF8S85W8V4J8Z60DC\n" +
+				"uuid parse user_01jhwp3ranf8s85w8v4j8z60dc\n" +
+				"uuid parse -o json < ids.txt",
+			Args: cobra.MaximumNArgs(1),
 			RunE: func(cmd *cobra.Command, args []string) error {
-				number, err := cmd.Flags().GetUint32(FlagNumber)
+				layout, err := cmd.Flags().GetString(FlagLayout)
 				if err != nil {
 					return err
 				}
 
-				namespace, err := cmd.Flags().GetString(FlagNamespace)
+				var fields []generate.Field
+				if layout != "" {
+					fields, err = generate.ParseLayout(layout)
+					if err != nil {
+						return fmt.Errorf("invalid layout: %w", err)
+					}
+				}
+
+				output, err := cmd.Flags().GetString(FlagOutput)
 				if err != nil {
 					return err
 				}
 
-				ns, err := uuid.FromString(namespace)
+				from, err := cmd.Flags().GetString(FlagFrom)
 				if err != nil {
-					return fmt.Errorf("invalid namespace: %w", err)
+					return err
 				}
 
-				return writeMany(int(number), cmd.OutOrStdout(), func() (string, error) {
-					return uuid.NewV5(ns, args[0]).String(), nil
-				})
-			},
-		}
-	)
-
-	applyFlags(cmd)
-
-	return cmd
-}
-
-func V6Cmd() *cobra.Command {
-	var (
-		applyFlags = MergeAppliers(
-			ApplyNumberFlag(),
-		)
-		cmd = &cobra.Command{
-			Use:     "v6",
-			Short:   "Generate UUID V6",
-			Long:    "K-sortable UUID based on a timestamp and 48 bits of pseudorandom data",
-			Example: "uuid v6",
-			RunE: func(cmd *cobra.Command, _ []string) error {
-				number, err := cmd.Flags().GetUint32(FlagNumber)
+				order, err := cmd.Flags().GetString(FlagByteOrder)
 				if err != nil {
 					return err
 				}
 
-				return writeMany(int(number), cmd.OutOrStdout(), func() (string, error) {
-					value, genErr := uuid.NewV6()
-					if genErr != nil {
-						return "", fmt.Errorf("generating UUID: %w", genErr)
+				var input = codec.Encoding{Format: from, Order: order}
+
+				if len(args) == 1 {
+					result, parseErr := inspect.Parse(args[0], input, fields)
+					if parseErr != nil {
+						return parseErr
 					}
 
-					return value.String(), nil
-				})
-			},
-		}
-	)
+					return inspect.Write(cmd.OutOrStdout(), output, result)
+				}
 
-	applyFlags(cmd)
+				cmd.SilenceUsage = true
 
-	return cmd
-}
+				var (
+					writer = bufio.NewWriter(cmd.OutOrStdout())
+					result summary
+				)
 
-func V7Cmd() *cobra.Command {
-	var (
-		applyFlags = MergeAppliers(
-			ApplyNumberFlag(),
-			ApplyEpocTime(),
-		)
-		cmd = &cobra.Command{
-			Use:     "v7",
-			Short:   "Generate UUID V7",
-			Long:    "K-sortable UUID based on the current millisecond precision",
-			Example: "uuid v7",
-			RunE: func(cmd *cobra.Command, _ []string) error {
-				number, err := cmd.Flags().GetUint32(FlagNumber)
+				err = readLines(cmd, func(number int, line string) error {
+					parsed, parseErr := inspect.Parse(line, input, fields)
+					result.add(number, parseErr == nil)
+					if parseErr != nil {
+						_, writeErr := fmt.Fprintf(cmd.ErrOrStderr(), "line %d: %s\n", number, parseErr)
+						return writeErr
+					}
+
+					if result.valid > 1 {
+						if writeErr := inspect.WriteSeparator(writer, output); writeErr != nil {
+							return writeErr
+						}
+					}
+
+					return inspect.Write(writer, output, parsed)
+				})
 				if err != nil {
 					return err
 				}
 
-				epochStr, err := cmd.Flags().GetString(FlagEpoch)
-				if err != nil {
+				if err = writer.Flush(); err != nil {
 					return err
 				}
 
-				epoch, err := time.Parse(time.RFC3339Nano, epochStr)
-				if err != nil {
-					return fmt.Errorf("invalid epoch format: %w", err)
+				if err = result.write(cmd.ErrOrStderr()); err != nil {
+					return err
 				}
 
-				return writeMany(int(number), cmd.OutOrStdout(), func() (string, error) {
-					value, genErr := uuid.NewV7AtTime(epoch)
-					if genErr != nil {
-						return "", fmt.Errorf("generating UUID: %w", genErr)
-					}
-
-					return value.String(), nil
-				})
+				return result.err()
 			},
 		}
 	)
@@ -242,72 +413,119 @@ func V7Cmd() *cobra.Command {
 	return cmd
 }
 
-func ParseCmd() *cobra.Command {
-	return &cobra.Command{
-		Use:     "parse [value]",
-		Short:   "Parse UUID value",
-		Long:    "Parses UUID value and outputs version details",
-		Example: "uuid parse 01ebb00e-d38a-11ef-8f83-426648c33d81",
-		Args:    cobra.ExactArgs(1),
-		RunE: func(cmd *cobra.Command, args []string) error {
-			value, err := uuid.FromString(args[0])
-			if err != nil {
-				return err
-			}
+// writeNamed writes the UUIDs derived from the name given as argument, or from
+// each name read from stdin (or --file) when no argument is given. Text values
+// are separated like those of writeMany, while rows of tsv and csv each end
+// with a newline.
+func writeNamed(cmd *cobra.Command, args []string, number uint64, enc codec.Encoding, output string, derive func(name string) uuid.UUID) error {
+	if len(args) == 0 && cmd.Flags().Changed(FlagNumber) {
+		return fmt.Errorf("--%s requires a value as argument: names read from stdin each get a single UUID", FlagNumber)
+	}
+
+	if len(args) == 1 && output == OutputText {
+		value := derive(args[0])
+
+		return writeMany(number, cmd.OutOrStdout(), enc, func() (uuid.UUID, error) {
+			return value, nil
+		})
+	}
+
+	var (
+		writer    = bufio.NewWriter(cmd.OutOrStdout())
+		csvWriter = csv.NewWriter(writer)
+		rows      int
+	)
+
+	writeRow := func(name string) error {
+		encoded, err := enc.Encode(derive(name))
+		if err != nil {
+			return err
+		}
 
-			switch value.Version() {
-			case 1:
-				v1, _ := uuid.TimestampFromV1(value)
-				ts, _ := v1.Time()
-
-				cmd.Printf("version: %v\n", value.Version())
-				cmd.Printf("time: %s\n", ts.Format(time.RFC3339Nano))
-			case 3:
-				cmd.Printf("version: %v\n", value.Version())
-			case 4:
-				cmd.Printf("version: %v\n", value.Version())
-			case 5:
-				cmd.Printf("version: %v\n", value.Version())
-			case 6:
-				v1, _ := uuid.TimestampFromV6(value)
-				ts, _ := v1.Time()
-
-				cmd.Printf("version: %v\n", value.Version())
-				cmd.Printf("time: %s\n", ts.Format(time.RFC3339Nano))
-			case 7:
-				v1, _ := uuid.TimestampFromV7(value)
-				ts, _ := v1.Time()
-
-				cmd.Printf("version: %v\n", value.Version())
-				cmd.Printf("time: %s\n", ts.Format(time.RFC3339Nano))
+		switch output {
+		case OutputText:
+			if rows > 0 {
+				_, err = writer.WriteString(enc.Separator())
 			}
+			if err == nil {
+				_, err = writer.WriteString(encoded)
+			}
+		case OutputTSV:
+			_, err = writer.WriteString(name + "\t" + encoded + "\n")
+		case OutputCSV:
+			err = csvWriter.Write([]string{name, encoded})
+			csvWriter.Flush()
+		default:
+			err = fmt.Errorf("unsupported output format %q", output)
+		}
+		rows++
 
-			return nil
-		},
+		return err
 	}
+
+	var err error
+	if len(args) == 1 {
+		for i := uint64(0); i < number && err == nil; i++ {
+			err = writeRow(args[0])
+		}
+	} else {
+		err = readLines(cmd, func(_ int, line string) error {
+			return writeRow(line)
+		})
+	}
+	if err != nil {
+		return err
+	}
+
+	return writer.Flush()
 }
 
-func writeMany(number int, writer io.Writer, generatorFunc func() (string, error)) error {
-	var sep = "\n"
+// writeMany writes the generated values through a buffered writer, encoding
+// each value straight into the free space of the buffer.
+func writeMany(number uint64, writer io.Writer, enc codec.Encoding, generatorFunc func() (uuid.UUID, error)) error {
+	var (
+		buffered = bufio.NewWriterSize(writer, writeBufferSize)
+		sep      = enc.Separator()
+	)
 
-	for i := 0; i < number; i++ {
-		var (
-			last       = i+1 == number
-			value, err = generatorFunc()
-		)
+	enc.Format = codec.Resolve(enc.Format)
+
+	for i := uint64(0); i < number; i++ {
+		value, err := generatorFunc()
 		if err != nil {
+			_ = buffered.Flush()
 			return err
 		}
 
-		if last {
-			sep = ""
+		var prefix string
+		if i > 0 {
+			prefix = sep
 		}
 
-		_, err = writer.Write([]byte(value + sep))
-		if err != nil {
+		if err = writeEncoded(buffered, enc, prefix, value, ""); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return buffered.Flush()
+}
+
+// writeEncoded writes the encoded value, between the prefix and suffix, into
+// the free space of the buffered writer.
+func writeEncoded(buffered *bufio.Writer, enc codec.Encoding, prefix string, value uuid.UUID, suffix string) error {
+	if buffered.Available() < codec.MaxEncodedSize {
+		if err := buffered.Flush(); err != nil {
+			return err
+		}
+	}
+
+	encoded, err := enc.AppendEncoded(append(buffered.AvailableBuffer(), prefix...), value)
+	if err != nil {
+		_ = buffered.Flush()
+		return err
+	}
+
+	_, err = buffered.Write(append(encoded, suffix...))
+
+	return err
 }
@@ -0,0 +1,74 @@
+package cmd_test
+
+import (
+	"crypto/rand"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/cmd"
+	"github.com/spf13/cobra"
+)
+
+// benchmarkCmd generates b.N values with the command in a single run, so the
+// time per operation is the time per generated value.
+func benchmarkCmd(b *testing.B, sut *cobra.Command, args []string, flags map[string]string) {
+	b.Helper()
+
+	sut.SetOut(io.Discard)
+	_ = sut.Flags().Set(cmd.FlagNumber, strconv.Itoa(b.N))
+	for name, value := range flags {
+		_ = sut.Flags().Set(name, value)
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	if err := sut.RunE(sut, args); err != nil {
+		b.Fatal(err)
+	}
+}
+
+func BenchmarkV1Cmd(b *testing.B) {
+	benchmarkCmd(b, cmd.V1Cmd(rand.Reader), nil, nil)
+}
+
+func BenchmarkV3Cmd(b *testing.B) {
+	benchmarkCmd(b, cmd.V3Cmd(uuid.NamespaceDNS, nil), []string{"example.com"}, nil)
+}
+
+func BenchmarkV4Cmd(b *testing.B) {
+	benchmarkCmd(b, cmd.V4Cmd(rand.Reader), nil, nil)
+}
+
+func BenchmarkV4CmdSeeded(b *testing.B) {
+	benchmarkCmd(b, cmd.V4Cmd(rand.Reader), nil, map[string]string{cmd.FlagSeed: "42"})
+}
+
+func BenchmarkV5Cmd(b *testing.B) {
+	benchmarkCmd(b, cmd.V5Cmd(uuid.NamespaceDNS, nil), []string{"example.com"}, nil)
+}
+
+func BenchmarkV6Cmd(b *testing.B) {
+	benchmarkCmd(b, cmd.V6Cmd(rand.Reader), nil, nil)
+}
+
+func BenchmarkV7Cmd(b *testing.B) {
+	benchmarkCmd(b, cmd.V7Cmd(rand.Reader), nil, nil)
+}
+
+func BenchmarkV8Cmd(b *testing.B) {
+	benchmarkCmd(b, cmd.V8Cmd(), nil, map[string]string{cmd.FlagData: "0x1234abcd"})
+}
+
+func BenchmarkFormats(b *testing.B) {
+	for _, format := range cmd.Formats {
+		b.Run(format, func(b *testing.B) {
+			benchmarkCmd(b, cmd.V4Cmd(rand.Reader), nil, map[string]string{
+				cmd.FlagSeed:   "42",
+				cmd.FlagFormat: format,
+			})
+		})
+	}
+}
@@ -1,21 +1,35 @@
 package cmd_test
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/json"
 	"fmt"
 	"github.com/legaard/uuidy/cmd"
 	"github.com/legaard/uuidy/internal/assert"
 	"strings"
+	"syscall"
 	"testing"
 	"time"
 
 	"github.com/gofrs/uuid/v5"
+	"github.com/spf13/cobra"
 )
 
+// newWriterMock returns a writer mock accepting all writes.
+func newWriterMock() *WriterMock {
+	return &WriterMock{
+		WriteFunc: func(p []byte) (int, error) {
+			return len(p), nil
+		},
+	}
+}
+
 func TestV1Cmd(t *testing.T) {
 	t.Run(`use is "v1"`, func(t *testing.T) {
 		// arrange
 		var (
-			sut = cmd.V1Cmd()
+			sut = cmd.V1Cmd(rand.Reader)
 		)
 
 		// act
@@ -28,8 +42,8 @@ func TestV1Cmd(t *testing.T) {
 	t.Run("generate UUID", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
-			sut        = cmd.V1Cmd()
+			writerMock = newWriterMock()
+			sut        = cmd.V1Cmd(rand.Reader)
 		)
 		sut.SetOut(writerMock)
 
@@ -47,9 +61,9 @@ func TestV1Cmd(t *testing.T) {
 	t.Run("generate multiple UUIDs", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			number     = 10
-			sut        = cmd.V1Cmd()
+			sut        = cmd.V1Cmd(rand.Reader)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagNumber, fmt.Sprintf("%d", number))
@@ -59,19 +73,73 @@ func TestV1Cmd(t *testing.T) {
 
 		// assert
 		assert.NoError(t, err)
-		assert.Equal(t, number, len(writerMock.WriteCalls()))
-		for _, call := range writerMock.WriteCalls() {
-			actual := strings.ReplaceAll(string(call.P), "\n", "") // remove new lines
+		assert.Equal(t, 1, len(writerMock.WriteCalls())) // buffered into a single write
+
+		lines := strings.Split(string(writerMock.WriteCalls()[0].P), "\n")
+		assert.Equal(t, number, len(lines))
+		for _, actual := range lines {
 			assert.UUIDVersion(t, 1, actual)
 		}
 	})
+
+	t.Run("generate identical UUIDs with seed and epoch", func(t *testing.T) {
+		// arrange
+		var (
+			first  = &bytes.Buffer{}
+			second = &bytes.Buffer{}
+			sut    = cmd.V1Cmd(rand.Reader)
+			sut2   = cmd.V1Cmd(rand.Reader)
+		)
+		sut.SetOut(first)
+		sut2.SetOut(second)
+		for _, c := range []*cobra.Command{sut, sut2} {
+			_ = c.Flags().Set(cmd.FlagSeed, "42")
+			_ = c.Flags().Set(cmd.FlagNumber, "10")
+			_ = c.Flags().Set(cmd.FlagEpoch, "2025-01-18T13:10:05+01:00")
+		}
+
+		// act
+		err := sut.RunE(sut, nil)
+		err2 := sut2.RunE(sut2, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, err2)
+		assert.Equal(t, first.String(), second.String())
+		for _, line := range strings.Split(first.String(), "\n") {
+			assert.UUIDVersion(t, 1, line)
+		}
+	})
+
+	t.Run("generate UUID with relative epoch", func(t *testing.T) {
+		// arrange
+		var (
+			output   = &bytes.Buffer{}
+			expected = time.Now().Add(-2 * time.Hour)
+			sut      = cmd.V1Cmd(rand.Reader)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagEpoch, "-2h")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+
+		ts, _ := uuid.TimestampFromV1(uuid.FromStringOrNil(output.String()))
+		actual, _ := ts.Time()
+		if diff := actual.Sub(expected); diff < -time.Microsecond || diff > time.Second {
+			t.Fatalf("expected %s, got %s", expected, actual)
+		}
+	})
 }
 
 func TestV3Cmd(t *testing.T) {
 	t.Run(`use is "v3 [value]"`, func(t *testing.T) {
 		// arrange
 		var (
-			sut = cmd.V3Cmd(uuid.NamespaceDNS)
+			sut = cmd.V3Cmd(uuid.NamespaceDNS, nil)
 		)
 
 		// act
@@ -84,8 +152,8 @@ func TestV3Cmd(t *testing.T) {
 	t.Run("generate UUID", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
-			sut        = cmd.V3Cmd(uuid.NamespaceDNS)
+			writerMock = newWriterMock()
+			sut        = cmd.V3Cmd(uuid.NamespaceDNS, nil)
 		)
 		sut.SetOut(writerMock)
 
@@ -103,9 +171,9 @@ func TestV3Cmd(t *testing.T) {
 	t.Run("generate multiple UUIDs", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			number     = 10
-			sut        = cmd.V3Cmd(uuid.NamespaceDNS)
+			sut        = cmd.V3Cmd(uuid.NamespaceDNS, nil)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagNumber, fmt.Sprintf("%d", number))
@@ -115,9 +183,11 @@ func TestV3Cmd(t *testing.T) {
 
 		// assert
 		assert.NoError(t, err)
-		assert.Equal(t, number, len(writerMock.WriteCalls()))
-		for _, call := range writerMock.WriteCalls() {
-			actual := strings.ReplaceAll(string(call.P), "\n", "") // remove new lines
+		assert.Equal(t, 1, len(writerMock.WriteCalls())) // buffered into a single write
+
+		lines := strings.Split(string(writerMock.WriteCalls()[0].P), "\n")
+		assert.Equal(t, number, len(lines))
+		for _, actual := range lines {
 			assert.UUIDVersion(t, 3, actual)
 		}
 	})
@@ -125,9 +195,9 @@ func TestV3Cmd(t *testing.T) {
 	t.Run("return error on invalid namespace", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			ns         = "invalid"
-			sut        = cmd.V3Cmd(uuid.NamespaceDNS)
+			sut        = cmd.V3Cmd(uuid.NamespaceDNS, nil)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagNamespace, ns)
@@ -142,9 +212,9 @@ func TestV3Cmd(t *testing.T) {
 	t.Run("generate uuid with namespace", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			ns         = uuid.Must(uuid.NewV4()).String()
-			sut        = cmd.V3Cmd(uuid.NamespaceDNS)
+			sut        = cmd.V3Cmd(uuid.NamespaceDNS, nil)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagNamespace, ns)
@@ -159,13 +229,49 @@ func TestV3Cmd(t *testing.T) {
 		actual := writerMock.WriteCalls()[0].P
 		assert.UUIDVersion(t, 3, string(actual))
 	})
+
+	t.Run("generate UUIDs for names from stdin", func(t *testing.T) {
+		// arrange
+		var (
+			input  = strings.NewReader("alice@example.com\n\n  bob@example.com \n")
+			output = &bytes.Buffer{}
+			sut    = cmd.V3Cmd(uuid.NamespaceDNS, nil)
+		)
+		sut.SetIn(input)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.NewV3(uuid.NamespaceDNS, "alice@example.com").String()+"\n"+
+			uuid.NewV3(uuid.NamespaceDNS, "bob@example.com").String(), output.String())
+	})
+
+	t.Run("return error on number without name", func(t *testing.T) {
+		// arrange
+		var (
+			input = strings.NewReader("alice@example.com\n")
+			sut   = cmd.V3Cmd(uuid.NamespaceDNS, nil)
+		)
+		sut.SetIn(input)
+		sut.SetOut(newWriterMock())
+		_ = sut.Flags().Set(cmd.FlagNumber, "2")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
 }
 
 func TestV4Cmd(t *testing.T) {
 	t.Run(`use is "v4"`, func(t *testing.T) {
 		// arrange
 		var (
-			sut = cmd.V4Cmd()
+			sut = cmd.V4Cmd(rand.Reader)
 		)
 
 		// act
@@ -178,8 +284,8 @@ func TestV4Cmd(t *testing.T) {
 	t.Run("generate UUID", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
-			sut        = cmd.V4Cmd()
+			writerMock = newWriterMock()
+			sut        = cmd.V4Cmd(rand.Reader)
 		)
 		sut.SetOut(writerMock)
 
@@ -197,11 +303,81 @@ func TestV4Cmd(t *testing.T) {
 	t.Run("generate multiple UUIDs", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			number     = 10
-			sut        = cmd.V4Cmd()
+			sut        = cmd.V4Cmd(rand.Reader)
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagNumber, fmt.Sprintf("%d", number))
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls())) // buffered into a single write
+
+		lines := strings.Split(string(writerMock.WriteCalls()[0].P), "\n")
+		assert.Equal(t, number, len(lines))
+		for _, actual := range lines {
+			assert.UUIDVersion(t, 4, actual)
+		}
+	})
+
+	t.Run("generate UUID from random source", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			random     = bytes.NewReader(make([]byte, 16))
+			sut        = cmd.V4Cmd(random)
 		)
 		sut.SetOut(writerMock)
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+		actual := writerMock.WriteCalls()[0].P
+		assert.Equal(t, "00000000-0000-4000-8000-000000000000", string(actual))
+	})
+
+	t.Run("generate identical UUIDs with seed", func(t *testing.T) {
+		// arrange
+		var (
+			first  = &bytes.Buffer{}
+			second = &bytes.Buffer{}
+			sut    = cmd.V4Cmd(rand.Reader)
+			sut2   = cmd.V4Cmd(rand.Reader)
+		)
+		sut.SetOut(first)
+		sut2.SetOut(second)
+		for _, c := range []*cobra.Command{sut, sut2} {
+			_ = c.Flags().Set(cmd.FlagSeed, "42")
+			_ = c.Flags().Set(cmd.FlagNumber, "10")
+		}
+
+		// act
+		err := sut.RunE(sut, nil)
+		err2 := sut2.RunE(sut2, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, err2)
+		assert.Equal(t, first.String(), second.String())
+		assert.NotEqual(t, "", first.String())
+	})
+
+	t.Run("generate more UUIDs than fit in the write buffer", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			number = 10_000
+			sut    = cmd.V4Cmd(rand.Reader)
+		)
+		sut.SetOut(output)
 		_ = sut.Flags().Set(cmd.FlagNumber, fmt.Sprintf("%d", number))
 
 		// act
@@ -209,19 +385,106 @@ func TestV4Cmd(t *testing.T) {
 
 		// assert
 		assert.NoError(t, err)
-		assert.Equal(t, number, len(writerMock.WriteCalls()))
-		for _, call := range writerMock.WriteCalls() {
-			actual := strings.ReplaceAll(string(call.P), "\n", "") // remove new lines
+
+		lines := strings.Split(output.String(), "\n")
+		assert.Equal(t, number, len(lines))
+
+		seen := make(map[string]bool, number)
+		for _, actual := range lines {
+			assert.UUIDVersion(t, 4, actual)
+			assert.Equal(t, false, seen[actual])
+			seen[actual] = true
+		}
+	})
+
+	t.Run("stream UUIDs for a duration", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.V4Cmd(rand.Reader)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagStream, "true")
+		_ = sut.Flags().Set(cmd.FlagDuration, "50ms")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, true, strings.HasSuffix(output.String(), "\n"))
+
+		lines := strings.Split(strings.TrimSuffix(output.String(), "\n"), "\n")
+		assert.Equal(t, true, len(lines) > 1)
+		for _, actual := range lines {
 			assert.UUIDVersion(t, 4, actual)
 		}
 	})
+
+	t.Run("stream UUIDs at a rate", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.V4Cmd(rand.Reader)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagStream, "true")
+		_ = sut.Flags().Set(cmd.FlagRate, "100")
+		_ = sut.Flags().Set(cmd.FlagDuration, "100ms")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+
+		// values are generated at 0ms, 10ms, ... 100ms at the most
+		actual := strings.Count(output.String(), "\n")
+		assert.Equalf(t, true, actual > 0 && actual <= 11, "expected at most 11 values, got %d", actual)
+	})
+
+	t.Run("stop streaming when the output is closed", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = &WriterMock{
+				WriteFunc: func(p []byte) (int, error) {
+					return 0, syscall.EPIPE
+				},
+			}
+			sut = cmd.V4Cmd(rand.Reader)
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagStream, "true")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+	})
+
+	t.Run("return error on rate without stream", func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.V4Cmd(rand.Reader)
+		)
+		sut.SetOut(newWriterMock())
+		_ = sut.Flags().Set(cmd.FlagRate, "10")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
 }
 
 func TestV5Cmd(t *testing.T) {
 	t.Run(`use is "v5 [value]"`, func(t *testing.T) {
 		// arrange
 		var (
-			sut = cmd.V5Cmd(uuid.NamespaceDNS)
+			sut = cmd.V5Cmd(uuid.NamespaceDNS, nil)
 		)
 
 		// act
@@ -234,8 +497,8 @@ func TestV5Cmd(t *testing.T) {
 	t.Run("generate UUID", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
-			sut        = cmd.V5Cmd(uuid.NamespaceDNS)
+			writerMock = newWriterMock()
+			sut        = cmd.V5Cmd(uuid.NamespaceDNS, nil)
 		)
 		sut.SetOut(writerMock)
 
@@ -253,9 +516,9 @@ func TestV5Cmd(t *testing.T) {
 	t.Run("generate multiple UUIDs", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			number     = 10
-			sut        = cmd.V5Cmd(uuid.NamespaceDNS)
+			sut        = cmd.V5Cmd(uuid.NamespaceDNS, nil)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagNumber, fmt.Sprintf("%d", number))
@@ -265,9 +528,11 @@ func TestV5Cmd(t *testing.T) {
 
 		// assert
 		assert.NoError(t, err)
-		assert.Equal(t, number, len(writerMock.WriteCalls()))
-		for _, call := range writerMock.WriteCalls() {
-			actual := strings.ReplaceAll(string(call.P), "\n", "") // remove new lines
+		assert.Equal(t, 1, len(writerMock.WriteCalls())) // buffered into a single write
+
+		lines := strings.Split(string(writerMock.WriteCalls()[0].P), "\n")
+		assert.Equal(t, number, len(lines))
+		for _, actual := range lines {
 			assert.UUIDVersion(t, 5, actual)
 		}
 	})
@@ -275,9 +540,9 @@ func TestV5Cmd(t *testing.T) {
 	t.Run("return error on invalid namespace", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			ns         = "invalid"
-			sut        = cmd.V5Cmd(uuid.NamespaceDNS)
+			sut        = cmd.V5Cmd(uuid.NamespaceDNS, nil)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagNamespace, ns)
@@ -292,9 +557,9 @@ func TestV5Cmd(t *testing.T) {
 	t.Run("generate uuid with namespace", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			ns         = uuid.Must(uuid.NewV4()).String()
-			sut        = cmd.V5Cmd(uuid.NamespaceDNS)
+			sut        = cmd.V5Cmd(uuid.NamespaceDNS, nil)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagNamespace, ns)
@@ -309,13 +574,182 @@ func TestV5Cmd(t *testing.T) {
 		actual := writerMock.WriteCalls()[0].P
 		assert.UUIDVersion(t, 5, string(actual))
 	})
+
+	t.Run("generate uuid with namespace alias", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.V5Cmd(uuid.Nil, nil)
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagNamespace, "url")
+
+		// act
+		err := sut.RunE(sut, []string{"testing"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+		actual := writerMock.WriteCalls()[0].P
+		assert.Equal(t, uuid.NewV5(uuid.NamespaceURL, "testing").String(), string(actual))
+	})
+
+	t.Run("generate uuid with derived namespace", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			namespaces = map[string]string{"acme": "dns:example.com/tenants"}
+			expected   = uuid.NewV5(uuid.NewV5(uuid.NewV5(uuid.NewV5(uuid.NamespaceDNS, "example.com"), "tenants"), "eu"), "testing")
+			sut        = cmd.V5Cmd(uuid.NamespaceDNS, namespaces)
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagNamespace, "acme:eu")
+
+		// act
+		err := sut.RunE(sut, []string{"testing"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+		actual := writerMock.WriteCalls()[0].P
+		assert.Equal(t, expected.String(), string(actual))
+	})
+
+	t.Run("return error on unknown namespace name", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.V5Cmd(uuid.NamespaceDNS, nil)
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagNamespace, "unknown:example.com")
+
+		// act
+		err := sut.RunE(sut, []string{"testing"})
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("generate name and UUID pairs from stdin", func(t *testing.T) {
+		for output, sep := range map[string]string{cmd.OutputTSV: "\t", cmd.OutputCSV: ","} {
+			// arrange
+			var (
+				input  = strings.NewReader("alice@example.com\nbob@example.com\n")
+				buffer = &bytes.Buffer{}
+				sut    = cmd.V5Cmd(uuid.NamespaceDNS, nil)
+			)
+			sut.SetIn(input)
+			sut.SetOut(buffer)
+			_ = sut.Flags().Set(cmd.FlagOutput, output)
+
+			// act
+			err := sut.RunE(sut, nil)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equalf(t, "alice@example.com"+sep+uuid.NewV5(uuid.NamespaceDNS, "alice@example.com").String()+"\n"+
+				"bob@example.com"+sep+uuid.NewV5(uuid.NamespaceDNS, "bob@example.com").String()+"\n", buffer.String(), "output: %s", output)
+		}
+	})
+
+	t.Run("return error on unsupported output", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.V5Cmd(uuid.NamespaceDNS, nil)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagOutput, "xml")
+
+		// act
+		err := sut.RunE(sut, []string{"testing"})
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+func TestV5CmdFormat(t *testing.T) {
+	for format, expected := range map[string]string{
+		cmd.FormatCanonical: "05b16a01-46c6-56dd-bd6e-c6dfb4a1427a",
+		cmd.FormatUpper:     "05B16A01-46C6-56DD-BD6E-C6DFB4A1427A",
+		cmd.FormatHex:       "05b16a0146c656ddbd6ec6dfb4a1427a",
+		cmd.FormatBraces:    "{05b16a01-46c6-56dd-bd6e-c6dfb4a1427a}",
+		cmd.FormatURN:       "urn:uuid:05b16a01-46c6-56dd-bd6e-c6dfb4a1427a",
+		cmd.FormatBase64:    "BbFqAUbGVt29bsbftKFCeg==",
+		cmd.FormatBase64URL: "BbFqAUbGVt29bsbftKFCeg",
+		cmd.FormatBase32:    "AWYWUAKGYZLN3PLOY3P3JIKCPI",
+		cmd.FormatBase58:    "hmtWcLBWDqJdAF5upbo6Z",
+		cmd.FormatCrockford: "05P5N02HP6AVEVTVP6VYTA2GKT",
+		cmd.FormatBinary:    string(uuid.FromStringOrNil("05b16a01-46c6-56dd-bd6e-c6dfb4a1427a").Bytes()),
+	} {
+		t.Run(fmt.Sprintf("generate UUID in %s format", format), func(t *testing.T) {
+			// arrange
+			var (
+				writerMock = newWriterMock()
+				sut        = cmd.V5Cmd(uuid.NamespaceDNS, nil)
+			)
+			sut.SetOut(writerMock)
+			_ = sut.Flags().Set(cmd.FlagFormat, format)
+
+			// act
+			err := sut.RunE(sut, []string{"x"})
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+			actual := writerMock.WriteCalls()[0].P
+			assert.Equal(t, expected, string(actual))
+		})
+	}
+
+	t.Run("generate UUID in byte order", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.V5Cmd(uuid.NamespaceDNS, nil)
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagFormat, cmd.FormatBytea)
+		_ = sut.Flags().Set(cmd.FlagByteOrder, cmd.ByteOrderMySQL)
+
+		// act
+		err := sut.RunE(sut, []string{"x"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+		actual := writerMock.WriteCalls()[0].P
+		assert.Equal(t, `\x56dd46c605b16a01bd6ec6dfb4a1427a`, string(actual))
+	})
+
+	t.Run("return error on unsupported format", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.V5Cmd(uuid.NamespaceDNS, nil)
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagFormat, "invalid")
+
+		// act
+		err := sut.RunE(sut, []string{"x"})
+
+		// assert
+		assert.Error(t, err)
+	})
 }
 
 func TestV6Cmd(t *testing.T) {
 	t.Run(`use is "v6"`, func(t *testing.T) {
 		// arrange
 		var (
-			sut = cmd.V6Cmd()
+			sut = cmd.V6Cmd(rand.Reader)
 		)
 
 		// act
@@ -328,8 +762,8 @@ func TestV6Cmd(t *testing.T) {
 	t.Run("generate UUID", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
-			sut        = cmd.V6Cmd()
+			writerMock = newWriterMock()
+			sut        = cmd.V6Cmd(rand.Reader)
 		)
 		sut.SetOut(writerMock)
 
@@ -347,9 +781,9 @@ func TestV6Cmd(t *testing.T) {
 	t.Run("generate multiple UUIDs", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			number     = 10
-			sut        = cmd.V6Cmd()
+			sut        = cmd.V6Cmd(rand.Reader)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagNumber, fmt.Sprintf("%d", number))
@@ -359,19 +793,50 @@ func TestV6Cmd(t *testing.T) {
 
 		// assert
 		assert.NoError(t, err)
-		assert.Equal(t, number, len(writerMock.WriteCalls()))
-		for _, call := range writerMock.WriteCalls() {
-			actual := strings.ReplaceAll(string(call.P), "\n", "") // remove new lines
+		assert.Equal(t, 1, len(writerMock.WriteCalls())) // buffered into a single write
+
+		lines := strings.Split(string(writerMock.WriteCalls()[0].P), "\n")
+		assert.Equal(t, number, len(lines))
+		for _, actual := range lines {
 			assert.UUIDVersion(t, 6, actual)
 		}
 	})
+
+	t.Run("generate identical UUIDs with seed and epoch", func(t *testing.T) {
+		// arrange
+		var (
+			first  = &bytes.Buffer{}
+			second = &bytes.Buffer{}
+			sut    = cmd.V6Cmd(rand.Reader)
+			sut2   = cmd.V6Cmd(rand.Reader)
+		)
+		sut.SetOut(first)
+		sut2.SetOut(second)
+		for _, c := range []*cobra.Command{sut, sut2} {
+			_ = c.Flags().Set(cmd.FlagSeed, "42")
+			_ = c.Flags().Set(cmd.FlagNumber, "10")
+			_ = c.Flags().Set(cmd.FlagEpoch, "2025-01-18T13:10:05+01:00")
+		}
+
+		// act
+		err := sut.RunE(sut, nil)
+		err2 := sut2.RunE(sut2, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, err2)
+		assert.Equal(t, first.String(), second.String())
+		for _, line := range strings.Split(first.String(), "\n") {
+			assert.UUIDVersion(t, 6, line)
+		}
+	})
 }
 
 func TestV7Cmd(t *testing.T) {
 	t.Run(`use is "v7"`, func(t *testing.T) {
 		// arrange
 		var (
-			sut = cmd.V7Cmd()
+			sut = cmd.V7Cmd(rand.Reader)
 		)
 
 		// act
@@ -384,8 +849,8 @@ func TestV7Cmd(t *testing.T) {
 	t.Run("generate UUID", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
-			sut        = cmd.V7Cmd()
+			writerMock = newWriterMock()
+			sut        = cmd.V7Cmd(rand.Reader)
 		)
 		sut.SetOut(writerMock)
 
@@ -403,9 +868,9 @@ func TestV7Cmd(t *testing.T) {
 	t.Run("generate multiple UUIDs", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			number     = 10
-			sut        = cmd.V7Cmd()
+			sut        = cmd.V7Cmd(rand.Reader)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagNumber, fmt.Sprintf("%d", number))
@@ -415,9 +880,11 @@ func TestV7Cmd(t *testing.T) {
 
 		// assert
 		assert.NoError(t, err)
-		assert.Equal(t, number, len(writerMock.WriteCalls()))
-		for _, call := range writerMock.WriteCalls() {
-			actual := strings.ReplaceAll(string(call.P), "\n", "") // remove new lines
+		assert.Equal(t, 1, len(writerMock.WriteCalls())) // buffered into a single write
+
+		lines := strings.Split(string(writerMock.WriteCalls()[0].P), "\n")
+		assert.Equal(t, number, len(lines))
+		for _, actual := range lines {
 			assert.UUIDVersion(t, 7, actual)
 		}
 	})
@@ -425,9 +892,9 @@ func TestV7Cmd(t *testing.T) {
 	t.Run("return error on invalid epoch", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			epoch      = "invalid"
-			sut        = cmd.V7Cmd()
+			sut        = cmd.V7Cmd(rand.Reader)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagEpoch, epoch)
@@ -442,9 +909,9 @@ func TestV7Cmd(t *testing.T) {
 	t.Run("generate uuid with epoch", func(t *testing.T) {
 		// arrange
 		var (
-			writerMock = &WriterMock{}
+			writerMock = newWriterMock()
 			epoch      = time.Now().Format(time.RFC3339Nano)
-			sut        = cmd.V7Cmd()
+			sut        = cmd.V7Cmd(rand.Reader)
 		)
 		sut.SetOut(writerMock)
 		_ = sut.Flags().Set(cmd.FlagEpoch, epoch)
@@ -459,37 +926,647 @@ func TestV7Cmd(t *testing.T) {
 		actual := writerMock.WriteCalls()[0].P
 		assert.UUIDVersion(t, 7, string(actual))
 	})
-}
 
-func TestNullCmd(t *testing.T) {
-	t.Run(`use is "null"`, func(t *testing.T) {
+	t.Run("generate identical UUIDs with seed and epoch", func(t *testing.T) {
 		// arrange
 		var (
-			sut = cmd.NullCmd()
+			first  = &bytes.Buffer{}
+			second = &bytes.Buffer{}
+			sut    = cmd.V7Cmd(rand.Reader)
+			sut2   = cmd.V7Cmd(rand.Reader)
 		)
+		sut.SetOut(first)
+		sut2.SetOut(second)
+		for _, c := range []*cobra.Command{sut, sut2} {
+			_ = c.Flags().Set(cmd.FlagSeed, "42")
+			_ = c.Flags().Set(cmd.FlagNumber, "10")
+			_ = c.Flags().Set(cmd.FlagEpoch, "2025-01-18T13:10:05+01:00")
+		}
 
 		// act
-		actual := sut.Use
+		err := sut.RunE(sut, nil)
+		err2 := sut2.RunE(sut2, nil)
 
 		// assert
-		assert.Equal(t, "null", actual)
+		assert.NoError(t, err)
+		assert.NoError(t, err2)
+		assert.Equal(t, first.String(), second.String())
+		for _, line := range strings.Split(first.String(), "\n") {
+			assert.UUIDVersion(t, 7, line)
+		}
 	})
 
-	t.Run("generate null UUID", func(t *testing.T) {
-		// arrange
+	t.Run("generate strictly increasing UUIDs with each method", func(t *testing.T) {
+		for _, method := range cmd.V7Methods {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				number = 4000
+				sut    = cmd.V7Cmd(rand.Reader)
+			)
+			sut.SetOut(output)
+			_ = sut.Flags().Set(cmd.FlagMethod, method)
+			_ = sut.Flags().Set(cmd.FlagNumber, fmt.Sprintf("%d", number))
+			_ = sut.Flags().Set(cmd.FlagEpoch, "2025-01-18T13:10:05+01:00")
+
+			// act
+			err := sut.RunE(sut, nil)
+
+			// assert
+			assert.NoErrorf(t, err, "method %s", method)
+
+			lines := strings.Split(output.String(), "\n")
+			assert.Equal(t, number, len(lines))
+			for i, actual := range lines {
+				assert.UUIDVersion(t, 7, actual)
+				if i > 0 && actual <= lines[i-1] {
+					t.Fatalf("method %s: value %d %s is not greater than %s", method, i, actual, lines[i-1])
+				}
+			}
+		}
+	})
+
+	t.Run("return error on counter overflow", func(t *testing.T) {
+		// a 12 bit counter and the 12 bit fraction both overflow within 5000 values
+		for _, method := range []string{cmd.MethodCounter, cmd.MethodPrecision} {
+			// arrange
+			var (
+				sut = cmd.V7Cmd(rand.Reader)
+			)
+			sut.SetOut(&bytes.Buffer{})
+			_ = sut.Flags().Set(cmd.FlagMethod, method)
+			_ = sut.Flags().Set(cmd.FlagCounter, "12")
+			_ = sut.Flags().Set(cmd.FlagNumber, "5000")
+			_ = sut.Flags().Set(cmd.FlagEpoch, "2025-01-18T13:10:05+01:00")
+
+			// act
+			err := sut.RunE(sut, nil)
+
+			// assert
+			assert.Errorf(t, err, "method %s", method)
+		}
+	})
+
+	t.Run("return error on invalid counter bits", func(t *testing.T) {
+		// arrange
 		var (
-			writerMock = &WriterMock{}
+			sut = cmd.V7Cmd(rand.Reader)
+		)
+		_ = sut.Flags().Set(cmd.FlagCounter, "43")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error on unsupported method", func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.V7Cmd(rand.Reader)
+		)
+		_ = sut.Flags().Set(cmd.FlagMethod, "sequence")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("generate UUID with flexible epoch", func(t *testing.T) {
+		var (
+			now       = time.Now()
+			midnight  = time.Date(now.UTC().Year(), now.UTC().Month(), now.UTC().Day(), 0, 0, 0, 0, time.UTC)
+			reference = time.Date(2025, 1, 18, 12, 10, 5, 123_000_000, time.UTC)
+		)
+
+		for epoch, expected := range map[string]time.Time{
+			"1737202205":              reference.Truncate(time.Second),
+			"1737202205.123":          reference,
+			"1737202205123":           reference,
+			"1737202205123456":        reference,
+			"1737202205123456789":     reference,
+			"2025-01-18":              time.Date(2025, 1, 18, 0, 0, 0, 0, time.UTC),
+			"2025-01-18 12:10:05.123": reference,
+			"yesterday":               midnight.AddDate(0, 0, -1),
+			"today+9h":                midnight.Add(9 * time.Hour),
+			"-2h":                     now.Add(-2 * time.Hour),
+			"now+15m":                 now.Add(15 * time.Minute),
+			"-1d":                     now.AddDate(0, 0, -1),
+		} {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				sut    = cmd.V7Cmd(rand.Reader)
+			)
+			sut.SetOut(output)
+			_ = sut.Flags().Set(cmd.FlagEpoch, epoch)
+			_ = sut.Flags().Set(cmd.FlagTimezone, "UTC")
+
+			// act
+			err := sut.RunE(sut, nil)
+
+			// assert
+			assert.NoErrorf(t, err, "epoch %s", epoch)
+
+			ts, _ := uuid.TimestampFromV7(uuid.FromStringOrNil(output.String()))
+			actual, _ := ts.Time()
+
+			// relative times are taken from the clock while running the command
+			if diff := actual.Sub(expected.Truncate(time.Millisecond)); diff < 0 || diff > time.Second {
+				t.Fatalf("epoch %s: expected %s, got %s", epoch, expected, actual)
+			}
+		}
+	})
+
+	t.Run("generate UUID with epoch in time zone", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.V7Cmd(rand.Reader)
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagEpoch, "2025-01-18 13:10:05")
+		_ = sut.Flags().Set(cmd.FlagTimezone, "Europe/Copenhagen")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+
+		ts, _ := uuid.TimestampFromV7(uuid.FromStringOrNil(output.String()))
+		actual, _ := ts.Time()
+		assert.Equal(t, "2025-01-18T12:10:05Z", actual.UTC().Format(time.RFC3339Nano))
+	})
+
+	t.Run("return error on invalid time zone", func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.V7Cmd(rand.Reader)
+		)
+		_ = sut.Flags().Set(cmd.FlagEpoch, "2025-01-18")
+		_ = sut.Flags().Set(cmd.FlagTimezone, "Mars/Base")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+func TestNullCmd(t *testing.T) {
+	t.Run(`use is "null"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.NullCmd()
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "null", actual)
+	})
+
+	t.Run("generate null UUID", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
 			sut        = cmd.NullCmd()
 		)
 		sut.SetOut(writerMock)
 
 		// act
-		sut.Run(sut, nil)
+		err := sut.RunE(sut, nil)
 
 		// assert
+		assert.NoError(t, err)
 		assert.Equal(t, 1, len(writerMock.WriteCalls()))
 
 		actual := writerMock.WriteCalls()[0].P
 		assert.Equal(t, "00000000-0000-0000-0000-000000000000", string(actual))
 	})
 }
+
+func TestMaxCmd(t *testing.T) {
+	t.Run(`use is "max"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.MaxCmd()
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "max", actual)
+	})
+
+	t.Run("generate max UUID", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.MaxCmd()
+		)
+		sut.SetOut(writerMock)
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+		actual := writerMock.WriteCalls()[0].P
+		assert.Equal(t, "ffffffff-ffff-ffff-ffff-ffffffffffff", string(actual))
+	})
+}
+
+func TestV8Cmd(t *testing.T) {
+	t.Run(`use is "v8"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.V8Cmd()
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "v8", actual)
+	})
+
+	t.Run("generate UUID from data", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.V8Cmd()
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagData, "0x3ffffffffffffffffffffffffffffff")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+		actual := writerMock.WriteCalls()[0].P
+		assert.Equal(t, "ffffffff-ffff-8fff-bfff-ffffffffffff", string(actual))
+	})
+
+	t.Run("generate UUID from layout", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.V8Cmd()
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagLayout, "shard:16,tenant:32")
+		_ = sut.Flags().Set(cmd.FlagField, "shard=3,tenant=0x2a")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, 1, len(writerMock.WriteCalls()))
+
+		actual := writerMock.WriteCalls()[0].P
+		assert.Equal(t, "00030000-002a-8000-8000-000000000000", string(actual))
+	})
+
+	t.Run("return error without data or layout", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.V8Cmd()
+		)
+		sut.SetOut(writerMock)
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error on data exceeding 122 bits", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.V8Cmd()
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagData, "0x4000000000000000000000000000000")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error on field value exceeding field bits", func(t *testing.T) {
+		// arrange
+		var (
+			writerMock = newWriterMock()
+			sut        = cmd.V8Cmd()
+		)
+		sut.SetOut(writerMock)
+		_ = sut.Flags().Set(cmd.FlagLayout, "shard:4")
+		_ = sut.Flags().Set(cmd.FlagField, "shard=16")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+func TestParseCmd(t *testing.T) {
+	t.Run(`use is "parse [value]"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.ParseCmd()
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "parse [value]", actual)
+	})
+
+	t.Run("report nil UUID", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"00000000-0000-0000-0000-000000000000"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "uuid: 00000000-0000-0000-0000-000000000000\nspecial: nil\nversion: 0\nvariant: ncs\n", output.String())
+	})
+
+	t.Run("report max UUID", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"ffffffff-ffff-ffff-ffff-ffffffffffff"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "uuid: ffffffff-ffff-ffff-ffff-ffffffffffff\nspecial: max\nversion: 15\nvariant: future\n", output.String())
+	})
+
+	t.Run("decompose V1 fields", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"01ebb00e-d38a-11ef-8f83-436648c33d81"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "uuid: 01ebb00e-d38a-11ef-8f83-436648c33d81\nversion: 1\nvariant: rfc9562\n"+
+			"time: 2025-01-15T21:45:16.294555Z\nunix_ms: 1736977516294\nclock_seq: 3971\n"+
+			"node: 43:66:48:c3:3d:81\nnode_random: true\n", output.String())
+	})
+
+	t.Run("decompose V7 fields", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"01947961-e155-7a32-82f1-1b2491f301ac"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "uuid: 01947961-e155-7a32-82f1-1b2491f301ac\nversion: 7\nvariant: rfc9562\n"+
+			"time: 2025-01-18T12:27:25.397Z\nunix_ms: 1737203245397\nrand_a: 0xa32\n"+
+			"rand_b: 0x02f11b2491f301ac\nrandom: 0x28c82f11b2491f301ac\n", output.String())
+	})
+
+	t.Run("decompose ULID", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"01JHWP3RANF8S85W8V4J8Z60DC"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "uuid: 01947961-e155-7a32-82f1-1b2491f301ac\nulid: 01JHWP3RANF8S85W8V4J8Z60DC\n"+
+			"time: 2025-01-18T12:27:25.397Z\nunix_ms: 1737203245397\n"+
+			"random: 0x7a3282f11b2491f301ac\n", output.String())
+	})
+
+	t.Run("return error on ULID exceeding 128 bits", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"81JHWP3RANF8S85W8V4J8Z60DC"})
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("decompose TypeID", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"user_01jhwp3ranf8s85w8v4j8z60dc"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "uuid: 01947961-e155-7a32-82f1-1b2491f301ac\ntypeid: user_01jhwp3ranf8s85w8v4j8z60dc\n"+
+			"version: 7\nvariant: rfc9562\ntime: 2025-01-18T12:27:25.397Z\nunix_ms: 1737203245397\nrand_a: 0xa32\n"+
+			"rand_b: 0x02f11b2491f301ac\nrandom: 0x28c82f11b2491f301ac\n", output.String())
+	})
+
+	for _, value := range []string{
+		"User_01jhwp3ranf8s85w8v4j8z60dc",
+		"_01jhwp3ranf8s85w8v4j8z60dc",
+		"user_01JHWP3RANF8S85W8V4J8Z60DC",
+		"user_81jhwp3ranf8s85w8v4j8z60dc",
+		"user_01jhwp3ranf8s85w8v4j8z60d",
+	} {
+		t.Run(fmt.Sprintf("return error on invalid TypeID %q", value), func(t *testing.T) {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				sut    = cmd.ParseCmd()
+			)
+			sut.SetOut(output)
+
+			// act
+			err := sut.RunE(sut, []string{value})
+
+			// assert
+			assert.Error(t, err)
+		})
+	}
+
+	t.Run("parse value in GUID byte order", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagFrom, cmd.FormatHex)
+		_ = sut.Flags().Set(cmd.FlagByteOrder, cmd.ByteOrderGUID)
+
+		// act
+		err := sut.RunE(sut, []string{"0eb0eb018ad3ef118f83426648c33d81"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, true, strings.HasPrefix(output.String(), "uuid: 01ebb00e-d38a-11ef-8f83-426648c33d81\nversion: 1\n"))
+	})
+
+	t.Run("output JSON", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagOutput, cmd.OutputJSON)
+
+		// act
+		err := sut.RunE(sut, []string{"01ebb00e-d38a-11ef-8f83-426648c33d81"})
+
+		// assert
+		assert.NoError(t, err)
+
+		var actual map[string]any
+		assert.NoError(t, json.Unmarshal(output.Bytes(), &actual))
+		assert.Equal(t, "01ebb00e-d38a-11ef-8f83-426648c33d81", actual["uuid"])
+		assert.Equal[any](t, float64(1), actual["version"])
+		assert.Equal[any](t, "rfc9562", actual["variant"])
+		assert.Equal[any](t, "2025-01-15T21:45:16.294555Z", actual["time"])
+		assert.Equal[any](t, float64(3971), actual["clock_seq"])
+		assert.Equal[any](t, "42:66:48:c3:3d:81", actual["node"])
+		assert.Equal[any](t, nil, actual["random"])
+	})
+
+	t.Run("output env", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagOutput, cmd.OutputEnv)
+
+		// act
+		err := sut.RunE(sut, []string{"00000000-0000-0000-0000-000000000000"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "UUID=00000000-0000-0000-0000-000000000000\nULID=\nTYPEID=\nSPECIAL=nil\nVERSION=0\nVARIANT=ncs\n"+
+			"TIME=\nUNIX_MS=\nCLOCK_SEQ=\nNODE=\nNODE_RANDOM=\nRAND_A=\nRAND_B=\nRANDOM=\nHASH=\nPAYLOAD=\n", output.String())
+	})
+
+	t.Run("return error on unsupported output", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagOutput, "xml")
+
+		// act
+		err := sut.RunE(sut, []string{"01ebb00e-d38a-11ef-8f83-426648c33d81"})
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("parse values from stdin", func(t *testing.T) {
+		// arrange
+		var (
+			input  = strings.NewReader("00000000-0000-0000-0000-000000000000\ninvalid\nffffffff-ffff-ffff-ffff-ffffffffffff\n")
+			output = &bytes.Buffer{}
+			errOut = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetIn(input)
+		sut.SetOut(output)
+		sut.SetErr(errOut)
+		_ = sut.Flags().Set(cmd.FlagOutput, cmd.OutputJSON)
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+
+		lines := strings.Split(strings.TrimSpace(output.String()), "\n")
+		assert.Equal(t, 2, len(lines))
+		for _, line := range lines {
+			var actual map[string]any
+			assert.NoError(t, json.Unmarshal([]byte(line), &actual))
+		}
+		assert.Equal(t, "line 2: uuid: incorrect UUID length 7 in string \"invalid\"\n2 valid, 1 invalid (lines: 2)\n", errOut.String())
+	})
+
+	t.Run("decode V8 layout", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ParseCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagLayout, "shard:16,tenant:32")
+
+		// act
+		err := sut.RunE(sut, []string{"00030000-002a-8000-8000-000000000000"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "uuid: 00030000-002a-8000-8000-000000000000\nversion: 8\nvariant: rfc9562\n"+
+			"payload: 0x0000c000000a8000000000000000000\nfields:\n  shard: 3\n  tenant: 42\n", output.String())
+	})
+}
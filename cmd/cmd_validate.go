@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"regexp"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/inspect"
+	"github.com/spf13/cobra"
+)
+
+var canonicalPattern = regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}$`)
+
+func ValidateCmd() *cobra.Command {
+	var (
+		applyFlags = MergeAppliers(
+			ApplyFileFlag(),
+			ApplyStrictFlag(),
+			ApplyVersionFlag(0, "required UUID version (0 accepts any version)"),
+		)
+		cmd = &cobra.Command{
+			Use:   "validate [value]",
+			Short: "Validate UUID values",
+			Long: "Validates a UUID value. Without a value, newline-delimited values are read from stdin (or --file)\n" +
+				"as a stream, the result is reported per line and a summary of the invalid lines is written to stderr.\n\n" +
+				"By default any value accepted by the parser is valid, including braces, URN prefixes and 32 char hex.\n" +
+				"With --strict, only the canonical lowercase hyphenated form with the RFC 9562 variant and a known\n" +
+				"version (1-8) is valid; the nil and max UUIDs are accepted as the special values they are.\n\n" +
+				"Exit codes:\n" +
+				"  2  invalid syntax\n" +
+				"  3  wrong variant\n" +
+				"  4  wrong version\n" +
+				"When reading several values, the exit code is the one of the first invalid value.",
+			Example: "uuid validate 01ebb00e-d38a-11ef-8f83-426648c33d81\n" +
+				"uuid validate --strict --version 7 --file ids.txt",
+			Args: cobra.MaximumNArgs(1),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				cmd.SilenceUsage = true
+
+				strict, err := cmd.Flags().GetBool(FlagStrict)
+				if err != nil {
+					return err
+				}
+
+				version, err := cmd.Flags().GetUint8(FlagVersion)
+				if err != nil {
+					return err
+				}
+
+				if len(args) == 1 {
+					if err = validateValue(args[0], strict, version); err != nil {
+						return err
+					}
+
+					cmd.Print("valid")
+
+					return nil
+				}
+
+				var (
+					writer   = bufio.NewWriter(cmd.OutOrStdout())
+					result   summary
+					firstErr *ExitError
+				)
+
+				err = readLines(cmd, func(number int, line string) error {
+					validateErr := validateValue(line, strict, version)
+					result.add(number, validateErr == nil)
+					if validateErr != nil {
+						if firstErr == nil {
+							errors.As(validateErr, &firstErr)
+						}
+
+						_, writeErr := fmt.Fprintf(writer, "line %d: invalid: %s\n", number, validateErr)
+						return writeErr
+					}
+
+					_, writeErr := fmt.Fprintf(writer, "line %d: valid\n", number)
+
+					return writeErr
+				})
+				if err != nil {
+					return err
+				}
+
+				if err = writer.Flush(); err != nil {
+					return err
+				}
+
+				if err = result.write(cmd.ErrOrStderr()); err != nil {
+					return err
+				}
+
+				if err = result.err(); err != nil {
+					return &ExitError{Code: firstErr.Code, Err: err}
+				}
+
+				return nil
+			},
+		}
+	)
+
+	applyFlags(cmd)
+
+	return cmd
+}
+
+// validateValue validates the value and returns an *ExitError with the exit
+// code matching the check that failed.
+func validateValue(value string, strict bool, version uint8) error {
+	if strict && !canonicalPattern.MatchString(value) {
+		return exitErrorf(ExitCodeInvalidSyntax, "%q is not a canonical lowercase hyphenated UUID", value)
+	}
+
+	parsed, err := uuid.FromString(value)
+	if err != nil {
+		return &ExitError{Code: ExitCodeInvalidSyntax, Err: err}
+	}
+
+	special := parsed == uuid.Nil || parsed == uuid.Max
+
+	if strict && !special && parsed.Variant() != uuid.VariantRFC9562 {
+		return exitErrorf(ExitCodeInvalidVariant, "%s: expected RFC 9562 variant, got %s", value, inspect.VariantName(parsed))
+	}
+
+	if strict && !special && (parsed.Version() < 1 || parsed.Version() > 8) {
+		return exitErrorf(ExitCodeInvalidVersion, "%s: unknown version %d", value, parsed.Version())
+	}
+
+	if version != 0 && parsed.Version() != version {
+		return exitErrorf(ExitCodeInvalidVersion, "%s: expected version %d, got %d", value, version, parsed.Version())
+	}
+
+	return nil
+}
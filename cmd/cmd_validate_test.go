@@ -0,0 +1,200 @@
+package cmd_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/legaard/uuidy/cmd"
+	"github.com/legaard/uuidy/internal/assert"
+)
+
+func TestValidateCmd(t *testing.T) {
+	t.Run(`use is "validate [value]"`, func(t *testing.T) {
+		// arrange
+		var (
+			sut = cmd.ValidateCmd()
+		)
+
+		// act
+		actual := sut.Use
+
+		// assert
+		assert.Equal(t, "validate [value]", actual)
+	})
+
+	t.Run("validate value", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ValidateCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"01ebb00e-d38a-11ef-8f83-426648c33d81"})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "valid", output.String())
+	})
+
+	t.Run("return error on invalid value", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ValidateCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"invalid"})
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("validate values from stdin", func(t *testing.T) {
+		// arrange
+		var (
+			input = strings.NewReader("01ebb00e-d38a-11ef-8f83-426648c33d81\ninvalid\n\n" +
+				"01947961-e155-7a32-82f1-1b2491f301ac\nalso-invalid\n")
+			output = &bytes.Buffer{}
+			errOut = &bytes.Buffer{}
+			sut    = cmd.ValidateCmd()
+		)
+		sut.SetIn(input)
+		sut.SetOut(output)
+		sut.SetErr(errOut)
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+		assert.Equal(t, "line 1: valid\n"+
+			"line 2: invalid: uuid: incorrect UUID length 7 in string \"invalid\"\n"+
+			"line 4: valid\n"+
+			"line 5: invalid: uuid: incorrect UUID length 12 in string \"also-invalid\"\n", output.String())
+		assert.Equal(t, "2 valid, 2 invalid (lines: 2, 5)\n", errOut.String())
+	})
+
+	t.Run("return exit code per failed strict check", func(t *testing.T) {
+		for value, code := range map[string]int{
+			"{01ebb00e-d38a-11ef-8f83-426648c33d81}": cmd.ExitCodeInvalidSyntax,
+			"01EBB00E-D38A-11EF-8F83-426648C33D81":   cmd.ExitCodeInvalidSyntax,
+			"01ebb00ed38a11ef8f83426648c33d81":       cmd.ExitCodeInvalidSyntax,
+			"01ebb00e-d38a-11ef-cf83-426648c33d81":   cmd.ExitCodeInvalidVariant,
+			"01ebb00e-d38a-01ef-8f83-426648c33d81":   cmd.ExitCodeInvalidVersion,
+		} {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				sut    = cmd.ValidateCmd()
+			)
+			sut.SetOut(output)
+			_ = sut.Flags().Set(cmd.FlagStrict, "true")
+
+			// act
+			err := sut.RunE(sut, []string{value})
+
+			// assert
+			var exitErr *cmd.ExitError
+			assert.Equalf(t, true, errors.As(err, &exitErr), "value: %s", value)
+			assert.Equalf(t, code, exitErr.Code, "value: %s", value)
+		}
+	})
+
+	t.Run("accept special values in strict mode", func(t *testing.T) {
+		for _, value := range []string{
+			"00000000-0000-0000-0000-000000000000",
+			"ffffffff-ffff-ffff-ffff-ffffffffffff",
+		} {
+			// arrange
+			var (
+				output = &bytes.Buffer{}
+				sut    = cmd.ValidateCmd()
+			)
+			sut.SetOut(output)
+			_ = sut.Flags().Set(cmd.FlagStrict, "true")
+
+			// act
+			err := sut.RunE(sut, []string{value})
+
+			// assert
+			assert.NoErrorf(t, err, "value: %s", value)
+		}
+	})
+
+	t.Run("accept non-canonical value without strict", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ValidateCmd()
+		)
+		sut.SetOut(output)
+
+		// act
+		err := sut.RunE(sut, []string{"urn:uuid:01ebb00e-d38a-11ef-8f83-426648c33d81"})
+
+		// assert
+		assert.NoError(t, err)
+	})
+
+	t.Run("return wrong version exit code", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ValidateCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagVersion, "7")
+
+		// act
+		err := sut.RunE(sut, []string{"01ebb00e-d38a-11ef-8f83-426648c33d81"})
+
+		// assert
+		var exitErr *cmd.ExitError
+		assert.Equal(t, true, errors.As(err, &exitErr))
+		assert.Equal(t, cmd.ExitCodeInvalidVersion, exitErr.Code)
+	})
+
+	t.Run("return exit code of first invalid value from stdin", func(t *testing.T) {
+		// arrange
+		var (
+			input  = strings.NewReader("01ebb00e-d38a-11ef-cf83-426648c33d81\ninvalid\n")
+			output = &bytes.Buffer{}
+			errOut = &bytes.Buffer{}
+			sut    = cmd.ValidateCmd()
+		)
+		sut.SetIn(input)
+		sut.SetOut(output)
+		sut.SetErr(errOut)
+		_ = sut.Flags().Set(cmd.FlagStrict, "true")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		var exitErr *cmd.ExitError
+		assert.Equal(t, true, errors.As(err, &exitErr))
+		assert.Equal(t, cmd.ExitCodeInvalidVariant, exitErr.Code)
+	})
+
+	t.Run("return error on missing file", func(t *testing.T) {
+		// arrange
+		var (
+			output = &bytes.Buffer{}
+			sut    = cmd.ValidateCmd()
+		)
+		sut.SetOut(output)
+		_ = sut.Flags().Set(cmd.FlagFile, "does-not-exist.txt")
+
+		// act
+		err := sut.RunE(sut, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,370 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// EnvPrefix is the prefix of the environment variables setting flags,
+	// e.g. UUIDY_NUMBER for --number and UUIDY_BYTE_ORDER for --byte-order,
+	// or UUIDY_BOUNDS_FROM for --from of the bounds command only.
+	EnvPrefix = "UUIDY_"
+
+	// EnvDefaultVersion is the environment variable selecting the version
+	// generated by the root command.
+	EnvDefaultVersion = EnvPrefix + "DEFAULT_VERSION"
+
+	// ProjectConfigName is the name of the project config file, looked up in
+	// the working directory and its parents.
+	ProjectConfigName = ".uuidy.yaml"
+
+	SourceDefault = "default"
+)
+
+// config is the content of a config file.
+type config struct {
+	// DefaultVersion is the version generated by the root command.
+	DefaultVersion string `yaml:"default_version"`
+	// Namespaces maps names to namespaces, given as a UUID or a derivation
+	// from one of the built-in aliases, e.g. "dns:example.com".
+	Namespaces map[string]string `yaml:"namespaces"`
+	// Flags maps flag names to the values used unless the flag is given. A
+	// command name maps to the values of the flags of that command only. The
+	// values are kept as nodes, so scalars are passed to the flags as written
+	// instead of as the numbers and times YAML decodes them into, e.g. 0x1234
+	// and 0755.
+	Flags map[string]yaml.Node `yaml:"flags"`
+}
+
+// annotationSettings is the annotation naming the command whose settings
+// apply to a command, e.g. the default version for the root command.
+const annotationSettings = "settings"
+
+// commandFlags lists the flags meaning different things in different
+// commands, e.g. --from is a time in bounds and a format in convert, which can
+// only be set for a command.
+var commandFlags = []string{FlagFrom, FlagTo, FlagOutput, FlagVersion}
+
+// Settings is the effective configuration, merged from the layers: built-in
+// defaults, the user config file, the project config file, environment
+// variables and, applied by cobra when parsing, the flags.
+type Settings struct {
+	defaultVersion setting
+	namespaces     map[string]string
+	flags          map[string]setting
+	commands       map[string]map[string]setting
+	files          []string
+	lookupEnv      func(key string) (string, bool)
+	errs           map[*cobra.Command][]error
+}
+
+// setting is a value and the source it was read from.
+type setting struct {
+	value  string
+	source string
+}
+
+// LoadSettings loads the config files in order of precedence, where missing
+// files are skipped, and reads environment variables with lookupEnv.
+func LoadSettings(paths []string, lookupEnv func(key string) (string, bool)) (*Settings, error) {
+	var settings = &Settings{
+		defaultVersion: setting{value: "4", source: SourceDefault},
+		namespaces:     map[string]string{},
+		flags:          map[string]setting{},
+		commands:       map[string]map[string]setting{},
+		lookupEnv:      lookupEnv,
+		errs:           map[*cobra.Command][]error{},
+	}
+
+	for _, path := range paths {
+		cfg, found, err := loadConfig(path)
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			continue
+		}
+
+		settings.files = append(settings.files, path)
+
+		if cfg.DefaultVersion != "" {
+			settings.defaultVersion = setting{value: cfg.DefaultVersion, source: path}
+		}
+
+		for name, ns := range cfg.Namespaces {
+			settings.namespaces[name] = ns
+		}
+
+		for name, value := range cfg.Flags {
+			node := resolveAlias(&value)
+			settings.flags[name] = setting{value: configValue(node), source: path}
+
+			// a map is either the value of a map flag such as --field or the
+			// values of a command, told apart by the names of the commands
+			// when the settings are applied
+			if node.Kind == yaml.MappingNode {
+				if settings.commands[name] == nil {
+					settings.commands[name] = map[string]setting{}
+				}
+
+				for i := 0; i+1 < len(node.Content); i += 2 {
+					flag := node.Content[i].Value
+					settings.commands[name][flag] = setting{value: configValue(resolveAlias(node.Content[i+1])), source: path}
+				}
+			}
+		}
+	}
+
+	if value, ok := lookupEnv(EnvDefaultVersion); ok && value != "" {
+		settings.defaultVersion = setting{value: value, source: "env " + EnvDefaultVersion}
+	}
+
+	return settings, nil
+}
+
+// DefaultVersion returns the version generated by the root command.
+func (s *Settings) DefaultVersion() string {
+	return s.defaultVersion.value
+}
+
+// Namespaces returns the named namespaces of the config files.
+func (s *Settings) Namespaces() map[string]string {
+	return s.namespaces
+}
+
+// Err returns the errors of values that could not be bound to the flags of
+// the command. Errors are only reported for the command being run, so a value
+// that does not fit the flag of one command does not break the others.
+func (s *Settings) Err(cmd *cobra.Command) error {
+	return errors.Join(s.errs[cmd]...)
+}
+
+// addErr adds the error of the command.
+func (s *Settings) addErr(cmd *cobra.Command, err error) {
+	s.errs[cmd] = append(s.errs[cmd], err)
+}
+
+// flag returns the setting of the flag of the command, if set by an
+// environment variable or a config file. Values for the command take
+// precedence over values for all commands, which are not accepted for the
+// flags of commandFlags. Environment variables for all commands are ignored
+// for those flags, as variables such as UUIDY_VERSION are commonly used for
+// other purposes.
+func (s *Settings) flag(command, name string) (setting, bool, error) {
+	var key = envName(command + "_" + name)
+	if value, ok := s.lookupEnv(key); ok {
+		return setting{value: value, source: "env " + key}, true, nil
+	}
+
+	key = envName(name)
+	if value, ok := s.lookupEnv(key); ok && !slices.Contains(commandFlags, name) {
+		return setting{value: value, source: "env " + key}, true, nil
+	}
+
+	if scoped, ok := s.commands[command][name]; ok {
+		return scoped, true, nil
+	}
+
+	global, ok := s.flags[name]
+	if ok && slices.Contains(commandFlags, name) {
+		return setting{}, false, fmt.Errorf("%s: --%s means different things in different commands, set it under flags.<command>.%s", global.source, name, name)
+	}
+
+	return global, ok, nil
+}
+
+// ApplySettings sets the flags of the command, and its subcommands, to the
+// values of the settings. The values become the defaults of the flags, so the
+// flags given on the command line, parsed later on, replace them.
+func ApplySettings(settings *Settings) FlagApplier {
+	return func(cmd *cobra.Command) {
+		cmd.Flags().VisitAll(func(f *pflag.Flag) {
+			if f.Name == "help" {
+				return
+			}
+
+			value, ok, err := settings.flag(settingsName(cmd), f.Name)
+			if err != nil {
+				settings.addErr(cmd, err)
+				return
+			}
+
+			if !ok {
+				return
+			}
+
+			if err = setDefault(f, value.value); err != nil {
+				settings.addErr(cmd, fmt.Errorf("%s: invalid value for --%s: %w", value.source, f.Name, err))
+			}
+		})
+
+		for _, sub := range cmd.Commands() {
+			ApplySettings(settings)(sub)
+		}
+	}
+}
+
+// settingsName returns the name of the command the settings of the command are
+// looked up by.
+func settingsName(cmd *cobra.Command) string {
+	if name, ok := cmd.Annotations[annotationSettings]; ok {
+		return name
+	}
+
+	return cmd.Name()
+}
+
+// setDefault sets the flag to the value without marking it as changed. Map
+// flags merge the values they are set to, so their value is set on a new
+// value, which is replaced by the values given on the command line.
+func setDefault(f *pflag.Flag, value string) error {
+	if f.Value.Type() != "stringToString" {
+		if err := f.Value.Set(value); err != nil {
+			return err
+		}
+
+		f.DefValue = f.Value.String()
+
+		return nil
+	}
+
+	var defaultValue = &settingValue{Value: newStringToString(), newValue: newStringToString}
+	if err := defaultValue.Value.Set(value); err != nil {
+		return err
+	}
+
+	f.Value = defaultValue
+	f.DefValue = f.Value.String()
+
+	return nil
+}
+
+// settingValue is the value of a map flag set by the settings. The first value
+// given on the command line starts over from an empty value instead of being
+// merged into the value of the settings.
+type settingValue struct {
+	pflag.Value
+	newValue func() pflag.Value
+	given    bool
+}
+
+func (v *settingValue) Set(value string) error {
+	if !v.given {
+		v.Value = v.newValue()
+		v.given = true
+	}
+
+	return v.Value.Set(value)
+}
+
+func newStringToString() pflag.Value {
+	var flags = pflag.NewFlagSet("", pflag.ContinueOnError)
+	flags.StringToString("value", nil, "")
+
+	return flags.Lookup("value").Value
+}
+
+// ConfigPaths returns the paths of the config files in order of precedence:
+// the user config file in the XDG config directory and the project config file.
+func ConfigPaths() ([]string, error) {
+	var paths []string
+
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+
+		dir = filepath.Join(home, ".config")
+	}
+	paths = append(paths, filepath.Join(dir, "uuidy", "config.yaml"))
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, err
+	}
+
+	for dir = wd; ; dir = filepath.Dir(dir) {
+		path := filepath.Join(dir, ProjectConfigName)
+		if _, statErr := os.Stat(path); statErr == nil {
+			paths = append(paths, path)
+			break
+		}
+
+		if filepath.Dir(dir) == dir {
+			break
+		}
+	}
+
+	return paths, nil
+}
+
+func loadConfig(path string) (config, bool, error) {
+	var cfg config
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return cfg, false, nil
+	}
+	if err != nil {
+		return cfg, false, fmt.Errorf("reading config: %w", err)
+	}
+
+	if err = yaml.Unmarshal(data, &cfg); err != nil {
+		return cfg, false, fmt.Errorf("invalid config %s: %w", path, err)
+	}
+
+	return cfg, true, nil
+}
+
+// configValue returns the value of a config file as a flag value, where lists
+// and maps are joined in the comma separated form pflag uses. Scalars are
+// returned as written in the file.
+func configValue(node *yaml.Node) string {
+	switch node.Kind {
+	case yaml.SequenceNode:
+		parts := make([]string, len(node.Content))
+		for i, item := range node.Content {
+			parts[i] = configValue(resolveAlias(item))
+		}
+		return strings.Join(parts, ",")
+	case yaml.MappingNode:
+		parts := make([]string, 0, len(node.Content)/2)
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			parts = append(parts, node.Content[i].Value+"="+configValue(resolveAlias(node.Content[i+1])))
+		}
+		sort.Strings(parts)
+		return strings.Join(parts, ",")
+	default:
+		if node.Tag == "!!null" {
+			return ""
+		}
+		return node.Value
+	}
+}
+
+// resolveAlias returns the node an alias, e.g. *defaults, refers to.
+func resolveAlias(node *yaml.Node) *yaml.Node {
+	for node.Kind == yaml.AliasNode && node.Alias != nil {
+		node = node.Alias
+	}
+
+	return node
+}
+
+func envName(flag string) string {
+	return EnvPrefix + strings.ToUpper(strings.ReplaceAll(flag, "-", "_"))
+}
@@ -1,26 +1,47 @@
 package cmd
 
 import (
+	"crypto/rand"
+	"fmt"
+	"os"
+	"strings"
+
 	"github.com/gofrs/uuid/v5"
 	"github.com/spf13/cobra"
 )
 
 func Execute(version string) error {
+	paths, err := ConfigPaths()
+	if err != nil {
+		return printError(err)
+	}
+
+	settings, err := LoadSettings(paths, os.LookupEnv)
+	if err != nil {
+		return printError(err)
+	}
+
 	var (
-		cliVersion           = version
-		defaultNamespace     = uuid.NamespaceDNS
-		defaultUUIDGenerator = uuid.NewV4
+		cliVersion = version
+		registry   = NewRegistry(rand.Reader, uuid.NamespaceDNS, settings.Namespaces())
+	)
+
+	defaultVersion, ok := registry.Lookup(settings.DefaultVersion())
+	if !ok {
+		return printError(fmt.Errorf("unsupported default version %q: must be one of %s", settings.DefaultVersion(), strings.Join(registry.Names(), ", ")))
+	}
 
-		root       = RootCmd(defaultUUIDGenerator)
+	var (
+		root       = RootCmd(registry.Command(defaultVersion))
 		versionCmd = VersionCmd(cliVersion)
-		v1         = V1Cmd()
-		v3         = V3Cmd(defaultNamespace)
-		v4         = V4Cmd()
-		v5         = V5Cmd(defaultNamespace)
-		v6         = V6Cmd()
-		v7         = V7Cmd()
+		configCmd  = ConfigCmd(settings)
 		parse      = ParseCmd()
+		validate   = ValidateCmd()
+		convert    = ConvertCmd()
+		bounds     = BoundsCmd()
+		serve      = ServeCmd(registry)
 		null       = NullCmd()
+		maxCmd     = MaxCmd()
 
 		uuidGroup = &cobra.Group{
 			ID:    "UUID_GROUP",
@@ -28,17 +49,32 @@ func Execute(version string) error {
 		}
 	)
 
-	v1.GroupID = uuidGroup.ID
-	v3.GroupID = uuidGroup.ID
-	v4.GroupID = uuidGroup.ID
-	v5.GroupID = uuidGroup.ID
-	v6.GroupID = uuidGroup.ID
-	v7.GroupID = uuidGroup.ID
-	parse.GroupID = uuidGroup.ID
-	null.GroupID = uuidGroup.ID
+	var uuidCmds = append(registry.Commands(), parse, validate, convert, bounds, null, maxCmd)
+	for _, c := range uuidCmds {
+		c.GroupID = uuidGroup.ID
+	}
 
 	root.AddGroup(uuidGroup)
-	root.AddCommand(versionCmd, v1, v3, v4, v5, v6, v7, parse, null)
+	root.AddCommand(versionCmd, configCmd, serve)
+	root.AddCommand(uuidCmds...)
+
+	ApplySettings(settings)(root)
+	root.PersistentPreRunE = func(cmd *cobra.Command, _ []string) error {
+		if err := settings.Err(cmd); err != nil {
+			cmd.SilenceUsage = true
+			return err
+		}
+
+		return nil
+	}
 
 	return root.Execute()
 }
+
+// printError prints errors occurring before the commands are executed, in the
+// same way cobra prints the errors returned by commands.
+func printError(err error) error {
+	fmt.Fprintf(os.Stderr, "Error: %s\n", err)
+
+	return err
+}
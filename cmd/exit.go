@@ -0,0 +1,29 @@
+package cmd
+
+import "fmt"
+
+// Exit codes used by the CLI, allowing scripts to tell failures apart.
+const (
+	ExitCodeError          = 1
+	ExitCodeInvalidSyntax  = 2
+	ExitCodeInvalidVariant = 3
+	ExitCodeInvalidVersion = 4
+)
+
+// ExitError is an error carrying the code the CLI should exit with.
+type ExitError struct {
+	Code int
+	Err  error
+}
+
+func (e *ExitError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ExitError) Unwrap() error {
+	return e.Err
+}
+
+func exitErrorf(code int, format string, args ...any) *ExitError {
+	return &ExitError{Code: code, Err: fmt.Errorf(format, args...)}
+}
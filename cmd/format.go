@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"github.com/legaard/uuidy/codec"
+	"github.com/legaard/uuidy/generate"
+	"github.com/legaard/uuidy/inspect"
+)
+
+const (
+	FormatCanonical = codec.Canonical
+	FormatUpper     = codec.Upper
+	FormatHex       = codec.Hex
+	FormatBraces    = codec.Braces
+	FormatURN       = codec.URN
+	FormatBase64    = codec.Base64
+	FormatBase64URL = codec.Base64URL
+	FormatBase32    = codec.Base32
+	FormatBase58    = codec.Base58
+	FormatCrockford = codec.Crockford
+	FormatTypeID    = codec.TypeID
+	FormatBinary    = codec.Binary
+	FormatInteger   = codec.Integer
+	FormatJava      = codec.Java
+	FormatBytea     = codec.Bytea
+	FormatAuto      = codec.Auto
+)
+
+const (
+	ByteOrderRFC   = codec.ByteOrderRFC
+	ByteOrderGUID  = codec.ByteOrderGUID
+	ByteOrderMySQL = codec.ByteOrderMySQL
+)
+
+// ByteOrders lists the supported byte orders.
+var ByteOrders = codec.ByteOrders
+
+// Formats lists the supported output formats.
+var Formats = codec.Formats
+
+const (
+	OutputText = inspect.OutputText
+	OutputJSON = inspect.OutputJSON
+	OutputYAML = inspect.OutputYAML
+	OutputEnv  = inspect.OutputEnv
+	OutputTSV  = "tsv"
+	OutputCSV  = "csv"
+)
+
+const (
+	MethodCounter   = generate.MethodCounter
+	MethodRandom    = generate.MethodRandom
+	MethodPrecision = generate.MethodPrecision
+
+	MinCounterBits = generate.MinCounterBits
+	MaxCounterBits = generate.MaxCounterBits
+)
+
+// V7Methods lists the supported methods of keeping V7 UUIDs monotonic.
+var V7Methods = generate.V7Methods
+
+// writeBufferSize is the size of the buffer values are written through.
+const writeBufferSize = 64 * 1024
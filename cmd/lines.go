@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// readLines calls fn for each non-empty line read from the file given by the
+// file flag, or from stdin when no file is given. Lines are read as a stream
+// and numbered from 1.
+func readLines(cmd *cobra.Command, fn func(number int, line string) error) error {
+	reader, err := openInput(cmd)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	scanner := bufio.NewScanner(reader)
+	for number := 1; scanner.Scan(); number++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if err = fn(number, line); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
+
+// readChunks calls fn for each chunk of the given size read from the file
+// given by the file flag, or from stdin when no file is given.
+func readChunks(cmd *cobra.Command, size int, fn func(chunk []byte) error) error {
+	reader, err := openInput(cmd)
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+
+	var chunk = make([]byte, size)
+	for {
+		_, err = io.ReadFull(reader, chunk)
+		switch {
+		case errors.Is(err, io.EOF):
+			return nil
+		case errors.Is(err, io.ErrUnexpectedEOF):
+			return fmt.Errorf("input is not a multiple of %d bytes", size)
+		case err != nil:
+			return err
+		}
+
+		if err = fn(chunk); err != nil {
+			return err
+		}
+	}
+}
+
+func openInput(cmd *cobra.Command) (io.ReadCloser, error) {
+	path, err := cmd.Flags().GetString(FlagFile)
+	if err != nil {
+		return nil, err
+	}
+
+	if path == "" || path == "-" {
+		return io.NopCloser(cmd.InOrStdin()), nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("opening file: %w", err)
+	}
+
+	return file, nil
+}
+
+// summary keeps track of the valid and invalid lines of a bulk operation.
+type summary struct {
+	valid   int
+	invalid []int
+}
+
+func (s *summary) add(number int, valid bool) {
+	if valid {
+		s.valid++
+		return
+	}
+
+	s.invalid = append(s.invalid, number)
+}
+
+func (s *summary) write(writer io.Writer) error {
+	var msg = fmt.Sprintf("%d valid, %d invalid", s.valid, len(s.invalid))
+	if len(s.invalid) > 0 {
+		lines := make([]string, len(s.invalid))
+		for i, number := range s.invalid {
+			lines[i] = fmt.Sprint(number)
+		}
+
+		msg += fmt.Sprintf(" (lines: %s)", strings.Join(lines, ", "))
+	}
+
+	_, err := fmt.Fprintln(writer, msg)
+
+	return err
+}
+
+func (s *summary) err() error {
+	if len(s.invalid) == 0 {
+		return nil
+	}
+
+	return fmt.Errorf("found %d invalid values", len(s.invalid))
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/legaard/uuidy/generate"
+	"github.com/spf13/cobra"
+)
+
+// randomBufferSize is the number of random bytes read at a time, so bulk
+// generation does not read from the random source for every value.
+const randomBufferSize = 4096
+
+// optionsOf returns the generator options of the seed and epoch flags of the
+// command, reading from the buffered random source unless a seed is set.
+// Without an epoch the current time is used for each value.
+func optionsOf(cmd *cobra.Command, random io.Reader) (generate.Options, error) {
+	seed, err := cmd.Flags().GetString(FlagSeed)
+	if err != nil {
+		return generate.Options{}, err
+	}
+
+	var opts = generate.Options{Seed: seed}
+	if seed == "" {
+		opts.Random = bufio.NewReaderSize(random, randomBufferSize)
+	}
+
+	if cmd.Flags().Lookup(FlagEpoch) == nil {
+		return opts, nil
+	}
+
+	epoch, fixed, err := epochOf(cmd)
+	if err != nil {
+		return generate.Options{}, err
+	}
+
+	if fixed {
+		opts.Now = func() time.Time {
+			return epoch
+		}
+	}
+
+	return opts, nil
+}
+
+// v7GeneratorOf returns a generator configured by the method, counter bits,
+// epoch and seed flags of the command.
+func v7GeneratorOf(cmd *cobra.Command, random io.Reader) (generate.Generator, error) {
+	method, err := cmd.Flags().GetString(FlagMethod)
+	if err != nil {
+		return nil, err
+	}
+
+	counterBits, err := cmd.Flags().GetUint(FlagCounter)
+	if err != nil {
+		return nil, err
+	}
+
+	// zero selects the default length in the library, but is invalid as a flag
+	if method == MethodCounter && counterBits == 0 {
+		return nil, fmt.Errorf("invalid counter bits %d: must be between %d and %d", counterBits, MinCounterBits, MaxCounterBits)
+	}
+
+	opts, err := optionsOf(cmd, random)
+	if err != nil {
+		return nil, err
+	}
+
+	return generate.NewV7(generate.V7Options{Options: opts, Method: method, CounterBits: counterBits})
+}
@@ -0,0 +1,329 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/codec"
+	"github.com/legaard/uuidy/generate"
+	"github.com/spf13/cobra"
+)
+
+// Version is a version of UUIDs, or another type of ID, generated by the CLI.
+// The command of the version, its route in the HTTP mode and the default
+// version of the root command are all derived from it.
+//
+// Generated versions set Generate and write --number values, or stream them
+// when the version applies the stream flags. With EncodeArgs, they take UUIDs
+// as arguments and write them in their format instead. Name-based versions set
+// Derive instead, get the namespace flag and take the name as argument or read
+// names from stdin.
+type Version struct {
+	// Name is the name of the command and of the route in the HTTP mode.
+	Name    string
+	Short   string
+	Long    string
+	Example string
+	// Type is the type of the values used in errors, UUID if empty.
+	Type string
+	// Format is the fixed format of the values, e.g. crockford for ULIDs.
+	// Versions without one get the format and byte order flags.
+	Format string
+	// Flags applies the flags of the version, on top of those shared by all
+	// generated or name-based versions.
+	Flags FlagApplier
+	// Generate returns the generator configured by the flags of the command.
+	Generate func(cmd *cobra.Command, random io.Reader) (generate.Generator, error)
+	// Derive returns the value of the name in the namespace.
+	Derive func(ns uuid.UUID, name string) uuid.UUID
+	// EncodeArgs makes a generated version encode the UUIDs given as
+	// arguments, decoded with the from and byte order flags, instead of
+	// generating values.
+	EncodeArgs bool
+	// Serve exposes the version in the HTTP mode.
+	Serve bool
+}
+
+func (v Version) typeName() string {
+	if v.Type == "" {
+		return "UUID"
+	}
+
+	return v.Type
+}
+
+// Registry holds the versions of the CLI, in the order they were registered,
+// and the random source and namespaces their commands are created with.
+type Registry struct {
+	random           io.Reader
+	defaultNamespace uuid.UUID
+	namespaces       map[string]string
+	versions         []Version
+}
+
+// NewRegistry returns a registry of the built-in versions.
+func NewRegistry(random io.Reader, defaultNamespace uuid.UUID, namespaces map[string]string) *Registry {
+	return &Registry{
+		random:           random,
+		defaultNamespace: defaultNamespace,
+		namespaces:       namespaces,
+		versions:         []Version{v1Version, v3Version, v4Version, v5Version, v6Version, v7Version, v8Version, ulidVersion, typeIDVersion},
+	}
+}
+
+// Register adds the version to the registry.
+func (r *Registry) Register(version Version) error {
+	switch {
+	case version.Name == "":
+		return errors.New("version without name")
+	case (version.Generate == nil) == (version.Derive == nil):
+		return fmt.Errorf("version %q must either generate or derive values", version.Name)
+	case version.EncodeArgs && version.Generate == nil:
+		return fmt.Errorf("version %q must generate values to encode arguments", version.Name)
+	}
+
+	if _, ok := r.Lookup(version.Name); ok {
+		return fmt.Errorf("version %q is already registered", version.Name)
+	}
+
+	r.versions = append(r.versions, version)
+
+	return nil
+}
+
+// Lookup returns the version of the name, accepting both "7" and "v7".
+func (r *Registry) Lookup(name string) (Version, bool) {
+	name = strings.ToLower(strings.TrimSpace(name))
+
+	for _, candidate := range []string{name, "v" + strings.TrimPrefix(name, "v")} {
+		for _, version := range r.versions {
+			if version.Name == candidate {
+				return version, true
+			}
+		}
+	}
+
+	return Version{}, false
+}
+
+// Versions returns the registered versions.
+func (r *Registry) Versions() []Version {
+	return r.versions
+}
+
+// Names returns the names of the registered versions.
+func (r *Registry) Names() []string {
+	var names = make([]string, 0, len(r.versions))
+	for _, version := range r.versions {
+		names = append(names, version.Name)
+	}
+
+	return names
+}
+
+// Commands returns a command for each registered version.
+func (r *Registry) Commands() []*cobra.Command {
+	var cmds = make([]*cobra.Command, 0, len(r.versions))
+	for _, version := range r.versions {
+		cmds = append(cmds, r.Command(version))
+	}
+
+	return cmds
+}
+
+// Command returns the command of the version.
+func (r *Registry) Command(version Version) *cobra.Command {
+	var (
+		applyFlags = MergeAppliers(ApplyNumberFlag())
+		cmd        = &cobra.Command{
+			Use:     version.Name,
+			Short:   version.Short,
+			Long:    version.Long,
+			Example: version.Example,
+		}
+	)
+
+	if version.Format == "" {
+		applyFlags = MergeAppliers(applyFlags, ApplyFormatFlag(), ApplyByteOrderFlag(FlagByteOrder))
+	}
+
+	if version.Derive != nil {
+		cmd.Use += " [value]"
+		cmd.Args = cobra.MaximumNArgs(1)
+		cmd.RunE = r.runDerive(version)
+		applyFlags = MergeAppliers(
+			applyFlags,
+			ApplyNamespaceFlag(r.defaultNamespace.String()),
+			ApplyFileFlag(),
+			ApplyOutputFlag(OutputText, OutputTSV, OutputCSV),
+		)
+	} else {
+		cmd.RunE = r.runGenerate(version)
+	}
+
+	if version.EncodeArgs {
+		cmd.Use += " [uuid...]"
+		cmd.Args = cobra.ArbitraryArgs
+		applyFlags = MergeAppliers(applyFlags, ApplyFromFormatFlag(FormatAuto))
+
+		if version.Format != "" {
+			applyFlags = MergeAppliers(applyFlags, ApplyByteOrderFlag(FlagByteOrder))
+		}
+	}
+
+	if version.Flags != nil {
+		applyFlags = MergeAppliers(applyFlags, version.Flags)
+	}
+
+	applyFlags(cmd)
+
+	return cmd
+}
+
+// runGenerate returns the run function of a generated version.
+func (r *Registry) runGenerate(version Version) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		number, err := cmd.Flags().GetUint64(FlagNumber)
+		if err != nil {
+			return err
+		}
+
+		enc, err := encodingOf(cmd, version)
+		if err != nil {
+			return err
+		}
+
+		if version.EncodeArgs && len(args) > 0 {
+			return writeArgs(cmd, args, enc)
+		}
+
+		gen, err := version.Generate(cmd, r.random)
+		if err != nil {
+			return err
+		}
+
+		generatorFunc := func() (uuid.UUID, error) {
+			value, genErr := gen.Next()
+			if genErr != nil {
+				return uuid.Nil, fmt.Errorf("generating %s: %w", version.typeName(), genErr)
+			}
+
+			return value, nil
+		}
+
+		if cmd.Flags().Lookup(FlagStream) == nil {
+			return writeMany(number, cmd.OutOrStdout(), enc, generatorFunc)
+		}
+
+		return writeGenerated(cmd, number, enc, generatorFunc)
+	}
+}
+
+// runDerive returns the run function of a name-based version.
+func (r *Registry) runDerive(version Version) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		number, err := cmd.Flags().GetUint64(FlagNumber)
+		if err != nil {
+			return err
+		}
+
+		enc, err := encodingOf(cmd, version)
+		if err != nil {
+			return err
+		}
+
+		ns, err := namespaceOf(cmd, r.namespaces)
+		if err != nil {
+			return err
+		}
+
+		output, err := cmd.Flags().GetString(FlagOutput)
+		if err != nil {
+			return err
+		}
+
+		return writeNamed(cmd, args, number, enc, output, func(name string) uuid.UUID {
+			return version.Derive(ns, name)
+		})
+	}
+}
+
+// writeArgs writes the UUIDs of the arguments, decoded with the from and byte
+// order flags, in the encoding.
+func writeArgs(cmd *cobra.Command, args []string, enc codec.Encoding) error {
+	from, err := cmd.Flags().GetString(FlagFrom)
+	if err != nil {
+		return err
+	}
+
+	order, err := cmd.Flags().GetString(FlagByteOrder)
+	if err != nil {
+		return err
+	}
+
+	var (
+		input = codec.Encoding{Format: from, Order: order}
+		next  int
+	)
+
+	return writeMany(uint64(len(args)), cmd.OutOrStdout(), enc, func() (uuid.UUID, error) {
+		value, decodeErr := input.Decode(args[next])
+		next++
+
+		return value, decodeErr
+	})
+}
+
+// namespaceOf returns the namespace of the namespace flag, resolving the named
+// namespaces.
+func namespaceOf(cmd *cobra.Command, namespaces map[string]string) (uuid.UUID, error) {
+	namespace, err := cmd.Flags().GetString(FlagNamespace)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	ns, err := generate.ResolveNamespace(namespace, namespaces)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid namespace: %w", err)
+	}
+
+	return ns, nil
+}
+
+// encodingOf returns the encoding of the format and byte order flags, or the
+// fixed format of the version, with the type prefix of the prefix flag.
+func encodingOf(cmd *cobra.Command, version Version) (codec.Encoding, error) {
+	var enc = codec.Encoding{Format: version.Format, Order: ByteOrderRFC}
+
+	if version.Format == "" {
+		format, err := cmd.Flags().GetString(FlagFormat)
+		if err != nil {
+			return codec.Encoding{}, err
+		}
+
+		order, err := cmd.Flags().GetString(FlagByteOrder)
+		if err != nil {
+			return codec.Encoding{}, err
+		}
+
+		enc = codec.Encoding{Format: format, Order: order}
+	}
+
+	if cmd.Flags().Lookup(FlagPrefix) != nil {
+		prefix, err := cmd.Flags().GetString(FlagPrefix)
+		if err != nil {
+			return codec.Encoding{}, err
+		}
+
+		if err = codec.ValidateTypePrefix(prefix); err != nil {
+			return codec.Encoding{}, err
+		}
+
+		enc.Prefix = prefix
+	}
+
+	return enc, nil
+}
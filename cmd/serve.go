@@ -0,0 +1,489 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/codec"
+	"github.com/legaard/uuidy/generate"
+	"github.com/legaard/uuidy/inspect"
+	"github.com/spf13/cobra"
+)
+
+// server exposes the versions of the registry over HTTP. The generators are
+// shared between requests and keep state between values, so they are guarded
+// by a mutex.
+type server struct {
+	routes     map[string]func(w http.ResponseWriter, r *http.Request) error
+	generators map[string]generate.Generator
+	mu         sync.Mutex
+	namespaces map[string]string
+	maxNumber  uint64
+	metrics    *serveMetrics
+}
+
+// serveError is an error with the HTTP status code of the response.
+type serveError struct {
+	code int
+	err  error
+}
+
+func (e *serveError) Error() string {
+	return e.err.Error()
+}
+
+func badRequest(format string, args ...any) *serveError {
+	return &serveError{code: http.StatusBadRequest, err: fmt.Errorf(format, args...)}
+}
+
+// newServer returns a server of the versions of the registry that are served.
+// The generators, namespaces and encodings are configured by the flags of the
+// commands of the versions next to the serve command, whose defaults are the
+// settings of the config files and environment.
+func newServer(registry *Registry, serve *cobra.Command, maxNumber uint64) (*server, error) {
+	var s = &server{
+		generators: make(map[string]generate.Generator),
+		namespaces: registry.namespaces,
+		maxNumber:  maxNumber,
+		metrics:    newServeMetrics(),
+	}
+
+	s.routes = map[string]func(w http.ResponseWriter, r *http.Request) error{
+		"/parse":   s.parse,
+		"/metrics": s.writeMetrics,
+		"/healthz": func(w http.ResponseWriter, _ *http.Request) error {
+			_, err := io.WriteString(w, "ok\n")
+			return err
+		},
+	}
+
+	for _, version := range registry.Versions() {
+		if !version.Serve {
+			continue
+		}
+
+		var cmd = versionCommand(registry, serve, version)
+
+		enc, err := encodingOf(cmd, version)
+		if err != nil {
+			return nil, err
+		}
+
+		if version.Derive != nil {
+			ns, nsErr := namespaceOf(cmd, registry.namespaces)
+			if nsErr != nil {
+				return nil, fmt.Errorf("%s: %w", version.Name, nsErr)
+			}
+
+			s.routes["/"+version.Name] = s.derive(version, ns, enc)
+			continue
+		}
+
+		gen, err := version.Generate(cmd, registry.random)
+		if err != nil {
+			return nil, fmt.Errorf("creating generator of %s: %w", version.Name, err)
+		}
+
+		s.generators[version.Name] = gen
+		s.routes["/"+version.Name] = s.generate(version, enc)
+	}
+
+	return s, nil
+}
+
+// versionCommand returns the command of the version next to the serve
+// command, or a new command of the version when serve has no parent.
+func versionCommand(registry *Registry, serve *cobra.Command, version Version) *cobra.Command {
+	if serve.HasParent() {
+		for _, cmd := range serve.Parent().Commands() {
+			if cmd.Name() == version.Name {
+				return cmd
+			}
+		}
+	}
+
+	return registry.Command(version)
+}
+
+// metricLabel returns the label of the version in the metrics, the version
+// number for UUIDs and the name for other IDs.
+func metricLabel(version Version) string {
+	return strings.TrimPrefix(version.Name, "v")
+}
+
+// handler returns the routes of the server:
+//
+//   - GET /{version} of generated versions generates n values (default 1)
+//   - GET /{version} of name-based versions derives a value for each name
+//     from the namespace ns
+//   - GET /parse/{id} returns the details of the value
+//   - GET /metrics returns the request metrics in the Prometheus text format
+//   - GET /healthz returns ok
+//
+// Values are encoded in the format of the format parameter. Responses are
+// text unless the output parameter is json or the Accept header asks for JSON.
+func (s *server) handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var (
+			start    = time.Now()
+			recorder = &statusRecorder{ResponseWriter: w, code: http.StatusOK}
+			route    = r.URL.Path
+		)
+
+		if strings.HasPrefix(route, "/parse/") {
+			route = "/parse"
+		}
+
+		handle, ok := s.routes[route]
+		switch {
+		case !ok:
+			route = "other"
+			writeError(recorder, r, &serveError{code: http.StatusNotFound, err: fmt.Errorf("not found: %s", r.URL.Path)})
+		case r.Method != http.MethodGet && r.Method != http.MethodHead:
+			recorder.Header().Set("Allow", "GET, HEAD")
+			writeError(recorder, r, &serveError{code: http.StatusMethodNotAllowed, err: fmt.Errorf("method %s not allowed", r.Method)})
+		default:
+			if err := handle(recorder, r); err != nil {
+				writeError(recorder, r, err)
+			}
+		}
+
+		s.metrics.observe(route, recorder.code, time.Since(start))
+	})
+}
+
+// generate returns a handler writing n values of the generator of the version
+// in the encoding enc, unless the format parameter is given.
+func (s *server) generate(version Version, enc codec.Encoding) func(w http.ResponseWriter, r *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		var (
+			query  = r.URL.Query()
+			number = uint64(1)
+			err    error
+		)
+
+		if n := query.Get("n"); n != "" {
+			if number, err = strconv.ParseUint(n, 10, 64); err != nil {
+				return badRequest("invalid n %q: must be a number", n)
+			}
+		}
+
+		if number > s.maxNumber {
+			return badRequest("invalid n %d: must be at most %d", number, s.maxNumber)
+		}
+
+		values, err := s.next(version.Name, number)
+		if err != nil {
+			return fmt.Errorf("generating %s: %w", version.typeName(), err)
+		}
+
+		s.metrics.generated(metricLabel(version), len(values))
+
+		return writeValues(w, r, enc, values)
+	}
+}
+
+// next returns number values of the generator of the version, holding the
+// lock so concurrent requests do not interleave.
+func (s *server) next(name string, number uint64) ([]uuid.UUID, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var (
+		gen    = s.generators[name]
+		values = make([]uuid.UUID, 0, number)
+	)
+
+	for i := uint64(0); i < number; i++ {
+		value, err := gen.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
+
+// derive returns a handler writing the value derived from the namespace of the
+// ns parameter, or the namespace ns, for each name parameter in the encoding
+// enc, unless the format parameter is given.
+func (s *server) derive(version Version, ns uuid.UUID, enc codec.Encoding) func(w http.ResponseWriter, r *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		var (
+			query = r.URL.Query()
+			names = query["name"]
+		)
+
+		if value := query.Get("ns"); value != "" {
+			resolved, err := generate.ResolveNamespace(value, s.namespaces)
+			if err != nil {
+				return badRequest("invalid namespace: %w", err)
+			}
+
+			ns = resolved
+		}
+
+		if len(names) == 0 {
+			return badRequest("missing name parameter")
+		}
+
+		if uint64(len(names)) > s.maxNumber {
+			return badRequest("too many names: must be at most %d", s.maxNumber)
+		}
+
+		var values = make([]uuid.UUID, 0, len(names))
+		for _, name := range names {
+			values = append(values, version.Derive(ns, name))
+		}
+
+		s.metrics.generated(metricLabel(version), len(values))
+
+		return writeValues(w, r, enc, values)
+	}
+}
+
+// parse writes the details of the value following /parse/, given in the
+// format of the from parameter (default canonical, also accepting ULIDs and
+// TypeIDs).
+func (s *server) parse(w http.ResponseWriter, r *http.Request) error {
+	var (
+		value = strings.TrimPrefix(r.URL.Path, "/parse/")
+		from  = r.URL.Query().Get("from")
+	)
+
+	if from == "" {
+		from = FormatCanonical
+	}
+
+	output, err := outputOf(r)
+	if err != nil {
+		return err
+	}
+
+	result, err := inspect.Parse(value, codec.Encoding{Format: from, Order: ByteOrderRFC}, nil)
+	if err != nil {
+		return badRequest("%w", err)
+	}
+
+	setContentType(w, output)
+
+	return inspect.Write(w, output, result)
+}
+
+// writeValues writes the values in the encoding enc, or in the format of the
+// format parameter, one per line or as a JSON object with a list of values.
+func writeValues(w http.ResponseWriter, r *http.Request, enc codec.Encoding, values []uuid.UUID) error {
+	if format := r.URL.Query().Get("format"); format != "" {
+		enc.Format = format
+	}
+
+	if codec.Resolve(enc.Format) == FormatBinary {
+		return badRequest("the %s format is not supported over HTTP", enc.Format)
+	}
+
+	output, err := outputOf(r)
+	if err != nil {
+		return err
+	}
+
+	var encoded = make([]string, 0, len(values))
+	for _, value := range values {
+		e, encodeErr := enc.Encode(value)
+		if encodeErr != nil {
+			return badRequest("%w", encodeErr)
+		}
+
+		encoded = append(encoded, e)
+	}
+
+	setContentType(w, output)
+
+	if output == OutputJSON {
+		return json.NewEncoder(w).Encode(struct {
+			UUIDs []string `json:"uuids"`
+		}{UUIDs: encoded})
+	}
+
+	var sb strings.Builder
+	for _, e := range encoded {
+		sb.WriteString(e)
+		sb.WriteString("\n")
+	}
+
+	_, err = io.WriteString(w, sb.String())
+
+	return err
+}
+
+// writeError writes the error with its status code, or 500 for errors without
+// one, in the output of the request.
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var (
+		code     = http.StatusInternalServerError
+		serveErr *serveError
+	)
+
+	if errors.As(err, &serveErr) {
+		code = serveErr.code
+	}
+
+	output, outputErr := outputOf(r)
+	if outputErr != nil {
+		output = OutputText
+	}
+
+	setContentType(w, output)
+	w.WriteHeader(code)
+
+	if output == OutputJSON {
+		_ = json.NewEncoder(w).Encode(struct {
+			Error string `json:"error"`
+		}{Error: err.Error()})
+		return
+	}
+
+	_, _ = io.WriteString(w, "error: "+err.Error()+"\n")
+}
+
+// outputOf returns the output of the output parameter, defaulting to json when
+// the Accept header asks for JSON and to text otherwise.
+func outputOf(r *http.Request) (string, error) {
+	switch output := r.URL.Query().Get("output"); output {
+	case OutputText, OutputJSON:
+		return output, nil
+	case "":
+		if strings.Contains(r.Header.Get("Accept"), "application/json") {
+			return OutputJSON, nil
+		}
+
+		return OutputText, nil
+	default:
+		return "", badRequest("unsupported output %q: must be %s or %s", output, OutputText, OutputJSON)
+	}
+}
+
+func setContentType(w http.ResponseWriter, output string) {
+	if output == OutputJSON {
+		w.Header().Set("Content-Type", "application/json")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+}
+
+// statusRecorder records the status code written to the response.
+type statusRecorder struct {
+	http.ResponseWriter
+	code int
+}
+
+func (r *statusRecorder) WriteHeader(code int) {
+	r.code = code
+	r.ResponseWriter.WriteHeader(code)
+}
+
+type requestKey struct {
+	route string
+	code  int
+}
+
+// serveMetrics counts the requests by route and status code, the time spent
+// handling them and the generated values by version.
+type serveMetrics struct {
+	mu        sync.Mutex
+	requests  map[requestKey]uint64
+	durations map[string]time.Duration
+	values    map[string]uint64
+}
+
+func newServeMetrics() *serveMetrics {
+	return &serveMetrics{
+		requests:  make(map[requestKey]uint64),
+		durations: make(map[string]time.Duration),
+		values:    make(map[string]uint64),
+	}
+}
+
+func (m *serveMetrics) observe(route string, code int, duration time.Duration) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.requests[requestKey{route: route, code: code}]++
+	m.durations[route] += duration
+}
+
+func (m *serveMetrics) generated(version string, n int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.values[version] += uint64(n)
+}
+
+// writeMetrics writes the metrics in the Prometheus text format, sorted so the
+// output is stable.
+func (s *server) writeMetrics(w http.ResponseWriter, _ *http.Request) error {
+	var (
+		m  = s.metrics
+		sb strings.Builder
+	)
+
+	m.mu.Lock()
+
+	sb.WriteString("# HELP uuidy_http_requests_total Number of HTTP requests by route and status code.\n")
+	sb.WriteString("# TYPE uuidy_http_requests_total counter\n")
+	var keys = make([]requestKey, 0, len(m.requests))
+	for key := range m.requests {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if keys[i].route != keys[j].route {
+			return keys[i].route < keys[j].route
+		}
+
+		return keys[i].code < keys[j].code
+	})
+	for _, key := range keys {
+		fmt.Fprintf(&sb, "uuidy_http_requests_total{route=%q,code=\"%d\"} %d\n", key.route, key.code, m.requests[key])
+	}
+
+	sb.WriteString("# HELP uuidy_http_request_duration_seconds_total Time spent handling HTTP requests by route.\n")
+	sb.WriteString("# TYPE uuidy_http_request_duration_seconds_total counter\n")
+	for _, route := range sortedKeys(m.durations) {
+		fmt.Fprintf(&sb, "uuidy_http_request_duration_seconds_total{route=%q} %g\n", route, m.durations[route].Seconds())
+	}
+
+	sb.WriteString("# HELP uuidy_generated_total Number of generated UUIDs by version.\n")
+	sb.WriteString("# TYPE uuidy_generated_total counter\n")
+	for _, version := range sortedKeys(m.values) {
+		fmt.Fprintf(&sb, "uuidy_generated_total{version=%q} %d\n", version, m.values[version])
+	}
+
+	m.mu.Unlock()
+
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, err := io.WriteString(w, sb.String())
+
+	return err
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	var keys = make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
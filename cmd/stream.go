@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/codec"
+	"github.com/spf13/cobra"
+)
+
+// streamOptions limits the values written in streaming mode, where a zero
+// rate or duration means no limit.
+type streamOptions struct {
+	rate     float64
+	duration time.Duration
+}
+
+// writeGenerated writes the number of generated values, or streams values
+// when the stream flag is set.
+func writeGenerated(cmd *cobra.Command, number uint64, enc codec.Encoding, generatorFunc func() (uuid.UUID, error)) error {
+	stream, err := cmd.Flags().GetBool(FlagStream)
+	if err != nil {
+		return err
+	}
+
+	var opts streamOptions
+
+	opts.rate, err = cmd.Flags().GetFloat64(FlagRate)
+	if err != nil {
+		return err
+	}
+
+	opts.duration, err = cmd.Flags().GetDuration(FlagDuration)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case opts.rate < 0:
+		return fmt.Errorf("invalid rate %v: must not be negative", opts.rate)
+	case opts.duration < 0:
+		return fmt.Errorf("invalid duration %s: must not be negative", opts.duration)
+	case !stream && (opts.rate != 0 || opts.duration != 0):
+		return fmt.Errorf("--%s and --%s require --%s", FlagRate, FlagDuration, FlagStream)
+	case !stream:
+		return writeMany(number, cmd.OutOrStdout(), enc, generatorFunc)
+	}
+
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// without a handler for SIGPIPE, writing to a closed stdout kills the
+	// process instead of returning EPIPE
+	pipe := make(chan os.Signal, 1)
+	signal.Notify(pipe, syscall.SIGPIPE)
+	defer signal.Stop(pipe)
+
+	return writeStream(ctx, cmd.OutOrStdout(), enc, opts, generatorFunc)
+}
+
+// writeStream writes generated values, each followed by the separator, until
+// the context is done, the duration has passed or the writer is closed. With a
+// rate, the values are spread evenly over time and flushed as they are
+// generated, so consumers receive them as a steady stream.
+func writeStream(ctx context.Context, writer io.Writer, enc codec.Encoding, opts streamOptions, generatorFunc func() (uuid.UUID, error)) error {
+	if opts.duration > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.duration)
+		defer cancel()
+	}
+
+	var (
+		buffered = bufio.NewWriterSize(writer, writeBufferSize)
+		sep      = enc.Separator()
+		start    = time.Now()
+		timer    *time.Timer
+	)
+
+	enc.Format = codec.Resolve(enc.Format)
+
+	err := func() error {
+		for i := uint64(0); ; i++ {
+			if opts.rate > 0 {
+				wait := time.Until(start.Add(time.Duration(float64(i) * float64(time.Second) / opts.rate)))
+				if wait > 0 {
+					if err := buffered.Flush(); err != nil {
+						return err
+					}
+
+					if timer == nil {
+						timer = time.NewTimer(wait)
+						defer timer.Stop()
+					} else {
+						timer.Reset(wait)
+					}
+
+					select {
+					case <-ctx.Done():
+						return nil
+					case <-timer.C:
+					}
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil
+			default:
+			}
+
+			value, err := generatorFunc()
+			if err != nil {
+				return err
+			}
+
+			if err = writeEncoded(buffered, enc, "", value, sep); err != nil {
+				return err
+			}
+		}
+	}()
+	if flushErr := buffered.Flush(); err == nil {
+		err = flushErr
+	}
+
+	// a closed output ends the stream like a signal does
+	if errors.Is(err, syscall.EPIPE) || errors.Is(err, io.ErrClosedPipe) || errors.Is(err, os.ErrClosed) {
+		return nil
+	}
+
+	return err
+}
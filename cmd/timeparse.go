@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+	// embedded so --tz works on systems without a time zone database
+	_ "time/tzdata"
+
+	"github.com/legaard/uuidy/generate"
+	"github.com/spf13/cobra"
+)
+
+// epochOf returns the time of the epoch flag, and whether it is set. Without
+// an epoch the current time is meant to be used for each value.
+func epochOf(cmd *cobra.Command) (time.Time, bool, error) {
+	value, err := cmd.Flags().GetString(FlagEpoch)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	if value == "" {
+		return time.Time{}, false, nil
+	}
+
+	loc, err := locationOf(cmd)
+	if err != nil {
+		return time.Time{}, false, err
+	}
+
+	epoch, err := generate.ParseTime(value, time.Now(), loc)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("invalid epoch: %w", err)
+	}
+
+	return epoch, true, nil
+}
+
+// locationOf returns the location of the tz flag, defaulting to the local time
+// zone.
+func locationOf(cmd *cobra.Command) (*time.Location, error) {
+	name, err := cmd.Flags().GetString(FlagTimezone)
+	if err != nil {
+		return nil, err
+	}
+
+	if name == "" || strings.EqualFold(name, "local") {
+		return time.Local, nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+
+	return loc, nil
+}
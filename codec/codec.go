@@ -0,0 +1,603 @@
+// Package codec encodes and decodes UUIDs in textual and binary formats, such
+// as the canonical form, base58, Crockford base32 (which ULIDs are written in)
+// and the byte orders databases store UUIDs in.
+package codec
+
+import (
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"math/bits"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	Canonical = "canonical"
+	Upper     = "upper"
+	Hex       = "hex"
+	Braces    = "braces"
+	URN       = "urn"
+	Base64    = "base64"
+	Base64URL = "base64url"
+	Base32    = "base32"
+	Base58    = "base58"
+	Crockford = "crockford"
+	Binary    = "binary"
+	Integer   = "integer"
+	Java      = "java"
+	Bytea     = "bytea"
+	Auto      = "auto"
+)
+
+const (
+	ByteOrderRFC   = "rfc"
+	ByteOrderGUID  = "guid"
+	ByteOrderMySQL = "mysql"
+)
+
+// ByteOrders lists the supported byte orders.
+var ByteOrders = []string{
+	ByteOrderRFC,
+	ByteOrderGUID,
+	ByteOrderMySQL,
+}
+
+// Formats lists the supported output formats.
+var Formats = []string{
+	Canonical,
+	Upper,
+	Hex,
+	Braces,
+	URN,
+	Base64,
+	Base64URL,
+	Base32,
+	Base58,
+	Crockford,
+	Binary,
+	Integer,
+	Java,
+	Bytea,
+}
+
+// formatAliases maps alternative names to the supported formats.
+var formatAliases = map[string]string{
+	"bytes": Binary,
+	// a ULID is a 128 bit value in Crockford base32
+	"ulid": Crockford,
+}
+
+const (
+	base58Alphabet    = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+)
+
+// MaxEncodedSize is the largest size of an encoded value, a TypeID with the
+// longest prefix, plus its separator.
+const MaxEncodedSize = MaxTypeIDPrefix + 1 + ULIDLength + 1
+
+var base32Encoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Encode returns the value in the given format.
+func Encode(format string, value uuid.UUID) (string, error) {
+	encoded, err := AppendEncoded(nil, format, value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// AppendEncoded appends the value in the given format to dst. The encoders
+// work on bytes without allocating, so bulk generation can encode straight
+// into the output buffer.
+func AppendEncoded(dst []byte, format string, value uuid.UUID) ([]byte, error) {
+	switch Resolve(format) {
+	case Canonical:
+		return appendCanonical(dst, value), nil
+	case Upper:
+		var start = len(dst)
+		dst = appendCanonical(dst, value)
+		for i := start; i < len(dst); i++ {
+			if dst[i] >= 'a' {
+				dst[i] -= 'a' - 'A'
+			}
+		}
+		return dst, nil
+	case Hex:
+		return appendHex(dst, value[:]), nil
+	case Braces:
+		dst = append(dst, '{')
+		dst = appendCanonical(dst, value)
+		return append(dst, '}'), nil
+	case URN:
+		dst = append(dst, "urn:uuid:"...)
+		return appendCanonical(dst, value), nil
+	case Base64:
+		return appendBase64(dst, base64.StdEncoding, value), nil
+	case Base64URL:
+		return appendBase64(dst, base64.RawURLEncoding, value), nil
+	case Base32:
+		return appendBase32(dst, value), nil
+	case Base58:
+		return appendBase58(dst, value), nil
+	case Crockford:
+		return appendCrockford(dst, value), nil
+	case TypeID:
+		return appendTypeID(dst, "", value), nil
+	case Binary:
+		return append(dst, value[:]...), nil
+	case Integer:
+		return appendUint128(dst, binary.BigEndian.Uint64(value[:8]), binary.BigEndian.Uint64(value[8:])), nil
+	case Java:
+		dst = strconv.AppendInt(dst, int64(binary.BigEndian.Uint64(value[:8])), 10)
+		dst = append(dst, ',')
+		return strconv.AppendInt(dst, int64(binary.BigEndian.Uint64(value[8:])), 10), nil
+	case Bytea:
+		dst = append(dst, `\x`...)
+		return appendHex(dst, value[:]), nil
+	default:
+		return dst, fmt.Errorf("unsupported format %q", format)
+	}
+}
+
+// Decode parses the value given in the format.
+func Decode(format string, value string) (uuid.UUID, error) {
+	var (
+		raw []byte
+		err error
+	)
+
+	switch Resolve(format) {
+	case Canonical, Upper, Hex, Braces, URN:
+		return uuid.FromString(value)
+	case Base64, Base64URL:
+		// accept both alphabets, with and without padding
+		normalized := strings.NewReplacer("-", "+", "_", "/").Replace(strings.TrimRight(value, "="))
+		raw, err = base64.RawStdEncoding.DecodeString(normalized)
+	case Base32:
+		raw, err = base32Encoding.DecodeString(strings.ToUpper(strings.TrimRight(value, "=")))
+	case Base58:
+		return decodeBase58(value)
+	case Crockford:
+		return decodeCrockford(value)
+	case TypeID:
+		_, decoded, typeIDErr := DecodeTypeID(value)
+		return decoded, typeIDErr
+	case Binary:
+		raw = []byte(value)
+	case Integer:
+		return decodeInteger(value)
+	case Java:
+		return decodeJava(value)
+	case Bytea:
+		raw, err = hex.DecodeString(strings.TrimPrefix(strings.Trim(value, "'"), `\x`))
+	default:
+		return uuid.Nil, fmt.Errorf("unsupported format %q", format)
+	}
+
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid %s value: %w", format, err)
+	}
+
+	return uuid.FromBytes(raw)
+}
+
+// Detect guesses the format of the value. Encodings that cannot be told
+// apart, e.g. a base32 value only using characters shared with Crockford
+// base32, are reported as ambiguous.
+func Detect(value string) (string, error) {
+	var lower = strings.ToLower(value)
+
+	switch {
+	case strings.HasPrefix(strings.TrimPrefix(value, "'"), `\x`):
+		return Bytea, nil
+	case strings.Contains(value, ","):
+		return Java, nil
+	case strings.HasPrefix(lower, "urn:uuid:"):
+		return URN, nil
+	case strings.HasPrefix(value, "{"):
+		return Braces, nil
+	case len(value) == 36 && strings.Count(value, "-") == 4:
+		return Canonical, nil
+	case len(value) == 32 && onlyChars(lower, "0123456789abcdef"):
+		return Hex, nil
+	case len(value) <= 39 && onlyChars(value, "0123456789"):
+		return Integer, nil
+	case len(value) == 24 && strings.HasSuffix(value, "=="),
+		len(value) == 22 && strings.ContainsAny(value, "+/-_0OIl"):
+		return Base64, nil
+	case len(value) == 26:
+		var upper = strings.ToUpper(value)
+		switch {
+		case strings.ContainsAny(upper, "0189"):
+			return Crockford, nil
+		case strings.ContainsAny(upper, "ILOU") || upper[0] > '7':
+			return Base32, nil
+		case !strings.ContainsRune("AEIMQUY4", rune(upper[25])):
+			// the last base32 character holds 3 bits followed by 2 zero bits
+			return Crockford, nil
+		}
+
+		return "", fmt.Errorf("ambiguous value %q: could be base32 or crockford, set the format explicitly", value)
+	case len(value) <= 22 && onlyChars(value, base58Alphabet):
+		return Base58, nil
+	}
+
+	return "", fmt.Errorf("unable to detect format of %q, set the format explicitly", value)
+}
+
+// Resolve returns the format an alias stands for, or the format itself.
+func Resolve(format string) string {
+	if alias, ok := formatAliases[format]; ok {
+		return alias
+	}
+
+	return format
+}
+
+func onlyChars(value string, chars string) bool {
+	for _, r := range value {
+		if !strings.ContainsRune(chars, r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// ToByteOrder returns the value with its bytes laid out in the byte order.
+// The guid order is the mixed-endian layout of SQL Server uniqueidentifier,
+// and the mysql order is the layout of UUID_TO_BIN(value, 1), which moves the
+// time_hi and time_mid fields in front of time_low.
+func ToByteOrder(order string, value uuid.UUID) (uuid.UUID, error) {
+	var u uuid.UUID
+
+	switch order {
+	case ByteOrderRFC, "":
+		return value, nil
+	case ByteOrderGUID:
+		u = value
+		u[0], u[1], u[2], u[3] = value[3], value[2], value[1], value[0]
+		u[4], u[5] = value[5], value[4]
+		u[6], u[7] = value[7], value[6]
+	case ByteOrderMySQL:
+		copy(u[0:2], value[6:8])
+		copy(u[2:4], value[4:6])
+		copy(u[4:8], value[0:4])
+		copy(u[8:], value[8:])
+	default:
+		return uuid.Nil, fmt.Errorf("unsupported byte order %q", order)
+	}
+
+	return u, nil
+}
+
+// FromByteOrder returns the value with its bytes laid out in the byte order
+// restored to the RFC 9562 order.
+func FromByteOrder(order string, value uuid.UUID) (uuid.UUID, error) {
+	var u uuid.UUID
+
+	switch order {
+	case ByteOrderRFC, ByteOrderGUID, "":
+		return ToByteOrder(order, value)
+	case ByteOrderMySQL:
+		copy(u[0:4], value[4:8])
+		copy(u[4:6], value[2:4])
+		copy(u[6:8], value[0:2])
+		copy(u[8:], value[8:])
+	default:
+		return uuid.Nil, fmt.Errorf("unsupported byte order %q", order)
+	}
+
+	return u, nil
+}
+
+// Encoding is a format combined with the byte order of the encoded bytes. An
+// empty byte order is the RFC 9562 order.
+type Encoding struct {
+	Format string
+	Order  string
+	// Prefix is the type prefix of TypeIDs, written before the value in the
+	// TypeID format.
+	Prefix string
+}
+
+// Encode returns the value in the format and byte order of the encoding.
+func (e Encoding) Encode(value uuid.UUID) (string, error) {
+	encoded, err := e.AppendEncoded(nil, value)
+	if err != nil {
+		return "", err
+	}
+
+	return string(encoded), nil
+}
+
+// AppendEncoded appends the value in the format and byte order of the
+// encoding to dst.
+func (e Encoding) AppendEncoded(dst []byte, value uuid.UUID) ([]byte, error) {
+	ordered, err := ToByteOrder(e.Order, value)
+	if err != nil {
+		return dst, err
+	}
+
+	if Resolve(e.Format) == TypeID {
+		return appendTypeID(dst, e.Prefix, ordered), nil
+	}
+
+	return AppendEncoded(dst, e.Format, ordered)
+}
+
+// Decode parses the value, detecting the format if it is auto.
+func (e Encoding) Decode(value string) (uuid.UUID, error) {
+	var format = e.Format
+	if format == Auto {
+		detected, err := Detect(value)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		format = detected
+	}
+
+	decoded, err := Decode(format, value)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return FromByteOrder(e.Order, decoded)
+}
+
+// Separator returns the separator written between values of the encoding.
+func (e Encoding) Separator() string {
+	return Separator(e.Format)
+}
+
+// Separator returns the separator written between values in the given format.
+func Separator(format string) string {
+	if Resolve(format) == Binary {
+		return ""
+	}
+
+	return "\n"
+}
+
+const hexDigits = "0123456789abcdef"
+
+func appendHex(dst []byte, src []byte) []byte {
+	for _, b := range src {
+		dst = append(dst, hexDigits[b>>4], hexDigits[b&0x0f])
+	}
+
+	return dst
+}
+
+func appendCanonical(dst []byte, value uuid.UUID) []byte {
+	dst = appendHex(dst, value[0:4])
+	dst = append(dst, '-')
+	dst = appendHex(dst, value[4:6])
+	dst = append(dst, '-')
+	dst = appendHex(dst, value[6:8])
+	dst = append(dst, '-')
+	dst = appendHex(dst, value[8:10])
+	dst = append(dst, '-')
+
+	return appendHex(dst, value[10:])
+}
+
+func appendBase64(dst []byte, enc *base64.Encoding, value uuid.UUID) []byte {
+	var (
+		start = len(dst)
+		n     = enc.EncodedLen(uuid.Size)
+	)
+
+	dst = slices.Grow(dst, n)[:start+n]
+	enc.Encode(dst[start:], value[:])
+
+	return dst
+}
+
+func appendBase32(dst []byte, value uuid.UUID) []byte {
+	var (
+		start = len(dst)
+		n     = base32Encoding.EncodedLen(uuid.Size)
+	)
+
+	dst = slices.Grow(dst, n)[:start+n]
+	base32Encoding.Encode(dst[start:], value[:])
+
+	return dst
+}
+
+// appendBase58 encodes the value by repeated long division of its bytes by 58,
+// where leading zero bytes are encoded as the first character of the alphabet.
+func appendBase58(dst []byte, value uuid.UUID) []byte {
+	var (
+		digits [22]byte
+		n      int
+		start  int
+	)
+
+	for start < len(value) && value[start] == 0 {
+		start++
+	}
+
+	for i := 0; i < start; i++ {
+		dst = append(dst, base58Alphabet[0])
+	}
+
+	for start < len(value) {
+		var rem uint
+		for i := start; i < len(value); i++ {
+			acc := rem<<8 | uint(value[i])
+			value[i] = byte(acc / 58)
+			rem = acc % 58
+		}
+
+		digits[n] = base58Alphabet[rem]
+		n++
+
+		for start < len(value) && value[start] == 0 {
+			start++
+		}
+	}
+
+	for i := n - 1; i >= 0; i-- {
+		dst = append(dst, digits[i])
+	}
+
+	return dst
+}
+
+// appendCrockford encodes the 128 bits as 26 Crockford base32 characters,
+// where the first character holds the 3 most significant bits.
+func appendCrockford(dst []byte, value uuid.UUID) []byte {
+	var (
+		hi = binary.BigEndian.Uint64(value[:8])
+		lo = binary.BigEndian.Uint64(value[8:])
+	)
+
+	for i := 25; i >= 0; i-- {
+		var (
+			shift = uint(i * 5)
+			digit uint64
+		)
+
+		if shift >= 64 {
+			digit = hi >> (shift - 64)
+		} else {
+			digit = lo>>shift | hi<<(64-shift)
+		}
+
+		dst = append(dst, crockfordAlphabet[digit&31])
+	}
+
+	return dst
+}
+
+// appendUint128 appends the decimal representation of the 128-bit integer
+// hi<<64 | lo, splitting it in chunks of 19 digits that fit in a uint64.
+func appendUint128(dst []byte, hi, lo uint64) []byte {
+	if hi == 0 {
+		return strconv.AppendUint(dst, lo, 10)
+	}
+
+	const chunk = 10_000_000_000_000_000_000
+
+	quotientLo, rem := bits.Div64(hi%chunk, lo, chunk)
+	dst = appendUint128(dst, hi/chunk, quotientLo)
+
+	var digits [19]byte
+	for i := len(digits) - 1; i >= 0; i-- {
+		digits[i] = byte('0' + rem%10)
+		rem /= 10
+	}
+
+	return append(dst, digits[:]...)
+}
+
+func decodeBase58(value string) (uuid.UUID, error) {
+	var (
+		num  = new(big.Int)
+		base = big.NewInt(58)
+		zero int
+	)
+
+	for i := 0; i < len(value); i++ {
+		digit := strings.IndexByte(base58Alphabet, value[i])
+		if digit < 0 {
+			return uuid.Nil, fmt.Errorf("invalid base58 character %q", value[i])
+		}
+
+		if digit == 0 && num.Sign() == 0 {
+			zero++
+		}
+
+		num.Mul(num, base)
+		num.Add(num, big.NewInt(int64(digit)))
+	}
+
+	if zero+len(num.Bytes()) > uuid.Size {
+		return uuid.Nil, fmt.Errorf("base58 value %q exceeds 128 bits", value)
+	}
+
+	return fromBigInt(num)
+}
+
+func decodeCrockford(value string) (uuid.UUID, error) {
+	if len(value) != 26 {
+		return uuid.Nil, fmt.Errorf("invalid crockford value %q: expected 26 characters", value)
+	}
+
+	// Crockford base32 is case-insensitive and maps easily confused
+	// characters to the digits they resemble
+	normalized := strings.NewReplacer("I", "1", "L", "1", "O", "0").Replace(strings.ToUpper(value))
+	if normalized[0] > '7' {
+		return uuid.Nil, fmt.Errorf("crockford value %q exceeds 128 bits", value)
+	}
+
+	var num = new(big.Int)
+	for i := 0; i < len(normalized); i++ {
+		digit := strings.IndexByte(crockfordAlphabet, normalized[i])
+		if digit < 0 {
+			return uuid.Nil, fmt.Errorf("invalid crockford character %q", value[i])
+		}
+
+		num.Lsh(num, 5)
+		num.Or(num, big.NewInt(int64(digit)))
+	}
+
+	return fromBigInt(num)
+}
+
+func decodeJava(value string) (uuid.UUID, error) {
+	var u uuid.UUID
+
+	msbStr, lsbStr, ok := strings.Cut(value, ",")
+	if !ok {
+		return uuid.Nil, fmt.Errorf("invalid java value %q: expected msb,lsb", value)
+	}
+
+	msb, err := strconv.ParseInt(strings.TrimSpace(msbStr), 10, 64)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid java value %q: %w", value, err)
+	}
+
+	lsb, err := strconv.ParseInt(strings.TrimSpace(lsbStr), 10, 64)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("invalid java value %q: %w", value, err)
+	}
+
+	binary.BigEndian.PutUint64(u[:8], uint64(msb))
+	binary.BigEndian.PutUint64(u[8:], uint64(lsb))
+
+	return u, nil
+}
+
+func decodeInteger(value string) (uuid.UUID, error) {
+	num, ok := new(big.Int).SetString(value, 10)
+	if !ok || num.Sign() < 0 {
+		return uuid.Nil, fmt.Errorf("invalid integer value %q", value)
+	}
+
+	return fromBigInt(num)
+}
+
+func fromBigInt(num *big.Int) (uuid.UUID, error) {
+	var value uuid.UUID
+
+	if num.BitLen() > 128 {
+		return uuid.Nil, fmt.Errorf("value %s exceeds 128 bits", num)
+	}
+
+	num.FillBytes(value[:])
+
+	return value, nil
+}
@@ -0,0 +1,139 @@
+package codec_test
+
+import (
+	"testing"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/codec"
+	"github.com/legaard/uuidy/internal/assert"
+)
+
+func TestEncoding(t *testing.T) {
+	var value = uuid.Must(uuid.FromString("01890a5d-ac96-774b-bcce-b302099a8057"))
+
+	for _, format := range codec.Formats {
+		for _, order := range codec.ByteOrders {
+			t.Run("round trip "+format+" in "+order+" byte order", func(t *testing.T) {
+				// arrange
+				var sut = codec.Encoding{Format: format, Order: order}
+
+				// act
+				encoded, encodeErr := sut.Encode(value)
+				decoded, decodeErr := sut.Decode(encoded)
+
+				// assert
+				assert.NoError(t, encodeErr)
+				assert.NoError(t, decodeErr)
+				assert.Equal(t, value, decoded)
+			})
+		}
+	}
+
+	t.Run("encode in crockford and base58", func(t *testing.T) {
+		// act
+		crockford, crockfordErr := codec.Encode(codec.Crockford, value)
+		base58, base58Err := codec.Encode(codec.Base58, value)
+
+		// assert
+		assert.NoError(t, crockfordErr)
+		assert.NoError(t, base58Err)
+		assert.Equal(t, "01H455VB4PEX5VSKNK084SN02Q", crockford)
+		assert.Equal(t, "BzmjTFLHWXwiSK4y3H5iW", base58)
+	})
+
+	t.Run("return error for unsupported format", func(t *testing.T) {
+		// act
+		_, err := codec.Encode("morse", value)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+func TestDetect(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected string
+	}{
+		{value: "01890a5d-ac96-774b-bcce-b302099a8057", expected: codec.Canonical},
+		{value: "{01890a5d-ac96-774b-bcce-b302099a8057}", expected: codec.Braces},
+		{value: "urn:uuid:01890a5d-ac96-774b-bcce-b302099a8057", expected: codec.URN},
+		{value: "01890a5dac96774bbcceb302099a8057", expected: codec.Hex},
+		{value: "01H455VB4PEX5VSKNK084SN02Q", expected: codec.Crockford},
+	}
+
+	for _, test := range tests {
+		t.Run("detect "+test.expected, func(t *testing.T) {
+			// act
+			actual, err := codec.Detect(test.value)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, test.expected, actual)
+		})
+	}
+
+	t.Run("return error for unknown format", func(t *testing.T) {
+		// act
+		_, err := codec.Detect("not a uuid")
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+func TestTypeID(t *testing.T) {
+	t.Run("encode TypeID of the specification", func(t *testing.T) {
+		// arrange
+		var value = uuid.Must(uuid.FromString("01890a5d-ac96-774b-bcce-b302099a8057"))
+
+		// act
+		actual := codec.EncodeTypeID("user", value)
+
+		// assert
+		assert.Equal(t, "user_01h455vb4pex5vsknk084sn02q", actual)
+	})
+
+	t.Run("encode TypeID with prefix of the encoding", func(t *testing.T) {
+		// arrange
+		var (
+			value = uuid.Must(uuid.FromString("01890a5d-ac96-774b-bcce-b302099a8057"))
+			sut   = codec.Encoding{Format: codec.TypeID, Order: codec.ByteOrderRFC, Prefix: "user"}
+		)
+
+		// act
+		actual, err := sut.Encode(value)
+		decoded, decodeErr := sut.Decode(actual)
+
+		// assert
+		assert.NoError(t, err)
+		assert.NoError(t, decodeErr)
+		assert.Equal(t, "user_01h455vb4pex5vsknk084sn02q", actual)
+		assert.Equal(t, value, decoded)
+	})
+
+	t.Run("decode TypeID of the specification", func(t *testing.T) {
+		// act
+		prefix, value, err := codec.DecodeTypeID("user_01h455vb4pex5vsknk084sn02q")
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "user", prefix)
+		assert.Equal(t, "01890a5d-ac96-774b-bcce-b302099a8057", value.String())
+	})
+
+	for _, typeID := range []string{
+		"User_01h455vb4pex5vsknk084sn02q",
+		"user_81h455vb4pex5vsknk084sn02q",
+		"user_01h455vb4pex5vsknk084sn02",
+		"_01h455vb4pex5vsknk084sn02q",
+	} {
+		t.Run("return error for invalid TypeID "+typeID, func(t *testing.T) {
+			// act
+			_, _, err := codec.DecodeTypeID(typeID)
+
+			// assert
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package codec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	// ULIDLength is the length of a ULID, 128 bits in Crockford base32.
+	ULIDLength = 26
+
+	// MaxTypeIDPrefix is the maximum length of the prefix of a TypeID.
+	MaxTypeIDPrefix = 63
+
+	typeIDAlphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+)
+
+// TypeID is the format of TypeIDs, encoding the value in lowercase Crockford
+// base32 after the prefix of the Encoding. It is not one of the Formats, as
+// the prefix is part of the value.
+const TypeID = "typeid"
+
+// EncodeULID returns the value as a ULID, in uppercase Crockford base32.
+func EncodeULID(value uuid.UUID) string {
+	return string(appendCrockford(nil, value))
+}
+
+// ValidateTypePrefix returns an error unless the prefix is a valid TypeID
+// prefix: at most 63 lowercase letters and underscores, starting and ending
+// with a letter. An empty prefix is valid.
+func ValidateTypePrefix(prefix string) error {
+	switch {
+	case prefix == "":
+		return nil
+	case len(prefix) > MaxTypeIDPrefix:
+		return fmt.Errorf("invalid prefix %q: must be at most %d characters", prefix, MaxTypeIDPrefix)
+	case !onlyChars(prefix, "abcdefghijklmnopqrstuvwxyz_"):
+		return fmt.Errorf("invalid prefix %q: must only contain lowercase letters and underscores", prefix)
+	case prefix[0] == '_' || prefix[len(prefix)-1] == '_':
+		return fmt.Errorf("invalid prefix %q: must start and end with a letter", prefix)
+	}
+
+	return nil
+}
+
+// EncodeTypeID returns the value as a TypeID: the prefix and the value in
+// lowercase Crockford base32, separated by an underscore. Without a prefix,
+// the TypeID is the encoded value alone.
+func EncodeTypeID(prefix string, value uuid.UUID) string {
+	return string(appendTypeID(nil, prefix, value))
+}
+
+func appendTypeID(dst []byte, prefix string, value uuid.UUID) []byte {
+	if prefix != "" {
+		dst = append(append(dst, prefix...), '_')
+	}
+
+	var start = len(dst)
+	dst = appendCrockford(dst, value)
+	for i := start; i < len(dst); i++ {
+		if dst[i] >= 'A' && dst[i] <= 'Z' {
+			dst[i] += 'a' - 'A'
+		}
+	}
+
+	return dst
+}
+
+// DecodeTypeID returns the prefix and the value of the TypeID.
+func DecodeTypeID(typeID string) (string, uuid.UUID, error) {
+	var prefix, suffix = "", typeID
+	if i := strings.LastIndexByte(typeID, '_'); i >= 0 {
+		prefix, suffix = typeID[:i], typeID[i+1:]
+		if prefix == "" {
+			return "", uuid.Nil, fmt.Errorf("invalid typeid %q: the prefix before the underscore is empty", typeID)
+		}
+	}
+
+	if err := ValidateTypePrefix(prefix); err != nil {
+		return "", uuid.Nil, fmt.Errorf("invalid typeid %q: %w", typeID, err)
+	}
+
+	if len(suffix) != ULIDLength || !onlyChars(suffix, typeIDAlphabet) {
+		return "", uuid.Nil, fmt.Errorf("invalid typeid %q: expected %d characters of lowercase crockford base32 after the prefix", typeID, ULIDLength)
+	}
+
+	value, err := decodeCrockford(suffix)
+	if err != nil {
+		return "", uuid.Nil, fmt.Errorf("invalid typeid %q: %w", typeID, err)
+	}
+
+	return prefix, value, nil
+}
+
+// IsTypeID reports whether the value looks like a prefixed TypeID, which is
+// longer than any textual UUID format containing an underscore.
+func IsTypeID(value string) bool {
+	return len(value) > ULIDLength+1 && strings.Contains(value, "_")
+}
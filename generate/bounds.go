@@ -0,0 +1,152 @@
+package generate
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// gregorianStart is the start of the Gregorian calendar, the epoch of the 60
+// bit timestamps of V1 and V6, which count 100ns intervals.
+var gregorianStart = time.Date(1582, 10, 15, 0, 0, 0, 0, time.UTC)
+
+const maxGregorianTime = 1<<60 - 1
+
+// Bounds returns the smallest UUID of the version for the time from, and the
+// largest for the time to, so time-ordered UUIDs can be queried by time. The
+// supported versions are 1, 6 and 7. An error is returned for times outside
+// the range of the timestamp of the version.
+func Bounds(version uint8, from, to time.Time) (uuid.UUID, uuid.UUID, error) {
+	switch version {
+	case 1, 6:
+		lower, err := gregorianTime(from)
+		if err != nil {
+			return uuid.Nil, uuid.Nil, err
+		}
+
+		upper, err := gregorianTime(to)
+		if err != nil {
+			return uuid.Nil, uuid.Nil, err
+		}
+
+		if version == 1 {
+			return timeBoundV1(lower, false), timeBoundV1(upper, true), nil
+		}
+
+		return timeBoundV6(lower, false), timeBoundV6(upper, true), nil
+	case 7:
+		lower, err := v7Time(from)
+		if err != nil {
+			return uuid.Nil, uuid.Nil, err
+		}
+
+		upper, err := v7Time(to)
+		if err != nil {
+			return uuid.Nil, uuid.Nil, err
+		}
+
+		return composeV7(lower, 0, 0), composeV7(upper, 1<<randABits-1, 1<<randBBits-1), nil
+	default:
+		return uuid.Nil, uuid.Nil, fmt.Errorf("unsupported version %d: must be 1, 6 or 7", version)
+	}
+}
+
+// gregorianTime returns the time as the number of 100ns intervals since the
+// start of the Gregorian calendar, or an error if the time is outside the
+// range of the timestamp of V1 and V6 UUIDs (1582-10-15 to 5236-03-31).
+func gregorianTime(t time.Time) (uint64, error) {
+	var seconds = t.Unix() - gregorianStart.Unix()
+	if t.Before(gregorianStart) || seconds > maxGregorianTime/10_000_000 {
+		return 0, fmt.Errorf("time %s is outside the range of V1 and V6 UUIDs", t.UTC().Format(time.RFC3339Nano))
+	}
+
+	var ts = uint64(seconds)*1e7 + uint64(t.Nanosecond()/100)
+	if ts > maxGregorianTime {
+		return 0, fmt.Errorf("time %s is outside the range of V1 and V6 UUIDs", t.UTC().Format(time.RFC3339Nano))
+	}
+
+	return ts, nil
+}
+
+func timeBoundV1(ts uint64, upper bool) uuid.UUID {
+	var u uuid.UUID
+
+	putTimeV1(&u, ts)
+	setClockSeqAndNode(&u, upper)
+
+	return u
+}
+
+func timeBoundV6(ts uint64, upper bool) uuid.UUID {
+	var u uuid.UUID
+
+	putTimeV6(&u, ts)
+	setClockSeqAndNode(&u, upper)
+
+	return u
+}
+
+// putTimeV1 sets the timestamp and version of a V1 UUID, where the low bits
+// of the timestamp come first.
+func putTimeV1(u *uuid.UUID, ts uint64) {
+	binary.BigEndian.PutUint32(u[0:4], uint32(ts))
+	binary.BigEndian.PutUint16(u[4:6], uint16(ts>>32))
+	binary.BigEndian.PutUint16(u[6:8], uint16(ts>>48)&0x0fff|uint16(uuid.V1)<<12)
+}
+
+// putTimeV6 sets the timestamp and version of a V6 UUID, where the high bits
+// of the timestamp come first.
+func putTimeV6(u *uuid.UUID, ts uint64) {
+	binary.BigEndian.PutUint32(u[0:4], uint32(ts>>28))
+	binary.BigEndian.PutUint16(u[4:6], uint16(ts>>12))
+	binary.BigEndian.PutUint16(u[6:8], uint16(ts)&0x0fff|uint16(uuid.V6)<<12)
+}
+
+// setClockSeqAndNode sets the clock sequence and node to the smallest or
+// largest value, keeping the RFC 9562 variant.
+func setClockSeqAndNode(u *uuid.UUID, upper bool) {
+	var fill byte
+	if upper {
+		fill = 0xff
+	}
+
+	for i := 8; i < len(u); i++ {
+		u[i] = fill
+	}
+
+	u[8] = u[8]&0x3f | 0x80
+}
+
+// ConvertVersion converts a V1 UUID to V6, or a V6 UUID to V1, keeping the
+// timestamp, clock sequence and node. Values of the version are returned as
+// they are.
+func ConvertVersion(value uuid.UUID, version uint8) (uuid.UUID, error) {
+	if value.Version() == version {
+		return value, nil
+	}
+
+	var converted = value
+
+	switch version {
+	case 1:
+		ts, err := uuid.TimestampFromV6(value)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("converting to V1: %w", err)
+		}
+
+		putTimeV1(&converted, uint64(ts))
+	case 6:
+		ts, err := uuid.TimestampFromV1(value)
+		if err != nil {
+			return uuid.Nil, fmt.Errorf("converting to V6: %w", err)
+		}
+
+		putTimeV6(&converted, uint64(ts))
+	default:
+		return uuid.Nil, fmt.Errorf("unsupported version %d: must be 1 or 6", version)
+	}
+
+	return converted, nil
+}
@@ -0,0 +1,136 @@
+// Package generate generates UUIDs of the time-based, random and custom
+// versions, and ULIDs, behind a common Generator interface. It also resolves
+// the namespaces of name-based UUIDs and parses the times generators are
+// given.
+package generate
+
+import (
+	"crypto/rand"
+	"errors"
+	"io"
+	"net"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// Generator generates UUIDs. Generators keep state between values and are not
+// safe for concurrent use.
+type Generator interface {
+	Next() (uuid.UUID, error)
+}
+
+// GeneratorFunc adapts a function to a Generator.
+type GeneratorFunc func() (uuid.UUID, error)
+
+func (f GeneratorFunc) Next() (uuid.UUID, error) {
+	return f()
+}
+
+// Options configures the source of random bits and the clock of a generator.
+type Options struct {
+	// Random is the source of random bits, crypto/rand if nil.
+	Random io.Reader
+	// Seed replaces Random by a deterministic stream of pseudorandom bytes
+	// derived from the seed, so the same values are generated on every run.
+	// Seeded V1 generators get a random node from the stream rather than the
+	// MAC address of the machine.
+	Seed string
+	// Now returns the time of each value, time.Now if nil. Use a function
+	// returning a fixed time to generate values for that time.
+	Now func() time.Time
+}
+
+func (o Options) random() io.Reader {
+	switch {
+	case o.Seed != "":
+		return NewSeededReader(o.Seed)
+	case o.Random != nil:
+		return o.Random
+	default:
+		return rand.Reader
+	}
+}
+
+func (o Options) now() func() time.Time {
+	if o.Now == nil {
+		return time.Now
+	}
+
+	return o.Now
+}
+
+func (o Options) gen() *uuid.Gen {
+	if o.Seed == "" {
+		return uuid.NewGenWithOptions(uuid.WithRandomReader(o.random()))
+	}
+
+	return uuid.NewGenWithOptions(
+		uuid.WithRandomReader(o.random()),
+		uuid.WithHWAddrFunc(func() (net.HardwareAddr, error) {
+			return nil, errors.New("seeded generator does not use hardware address")
+		}),
+	)
+}
+
+// NewV1 returns a generator of V1 UUIDs, based on the time and the MAC address.
+// Times outside the range of the timestamp of V1 UUIDs are rejected.
+func NewV1(opts Options) Generator {
+	var gen = opts.gen()
+	if opts.Now == nil {
+		return GeneratorFunc(gen.NewV1)
+	}
+
+	return GeneratorFunc(func() (uuid.UUID, error) {
+		var now = opts.Now()
+
+		ts, err := gregorianTime(now)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		// the timestamp of the generator wraps after 2262, so it is replaced
+		// by one covering the full range
+		u, err := gen.NewV1AtTime(now)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		putTimeV1(&u, ts)
+
+		return u, nil
+	})
+}
+
+// NewV4 returns a generator of random V4 UUIDs.
+func NewV4(opts Options) Generator {
+	return GeneratorFunc(opts.gen().NewV4)
+}
+
+// NewV6 returns a generator of V6 UUIDs, V1 UUIDs with the timestamp
+// reordered so they sort by time. Times outside the range of the timestamp of
+// V6 UUIDs are rejected.
+func NewV6(opts Options) Generator {
+	var gen = opts.gen()
+	if opts.Now == nil {
+		return GeneratorFunc(gen.NewV6)
+	}
+
+	return GeneratorFunc(func() (uuid.UUID, error) {
+		var now = opts.Now()
+
+		ts, err := gregorianTime(now)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		// the timestamp of the generator wraps after 2262, so it is replaced
+		// by one covering the full range
+		u, err := gen.NewV6AtTime(now)
+		if err != nil {
+			return uuid.Nil, err
+		}
+		putTimeV6(&u, ts)
+
+		return u, nil
+	})
+}
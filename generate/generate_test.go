@@ -0,0 +1,316 @@
+package generate_test
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/generate"
+	"github.com/legaard/uuidy/internal/assert"
+)
+
+var epoch = time.Date(2025, 1, 18, 12, 27, 25, 397_000_000, time.UTC)
+
+func fixed() time.Time {
+	return epoch
+}
+
+func next(t *testing.T, gen generate.Generator, n int) []string {
+	t.Helper()
+
+	var values = make([]string, 0, n)
+	for i := 0; i < n; i++ {
+		value, err := gen.Next()
+		assert.NoError(t, err)
+
+		values = append(values, value.String())
+	}
+
+	return values
+}
+
+func TestGenerators(t *testing.T) {
+	var opts = generate.Options{Seed: "lib", Now: fixed}
+
+	t.Run("generate seeded V4 UUID", func(t *testing.T) {
+		// arrange
+		var sut = generate.NewV4(opts)
+
+		// act
+		actual := next(t, sut, 1)
+
+		// assert
+		assert.Equal(t, []string{"c4429e2b-72f5-4bab-af02-35b46719af96"}, actual)
+	})
+
+	t.Run("generate seeded V6 UUID at fixed time", func(t *testing.T) {
+		// arrange
+		var sut = generate.NewV6(opts)
+
+		// act
+		actual := next(t, sut, 1)
+
+		// assert
+		assert.Equal(t, []string{"1efd5979-2f34-6850-9e2b-72f5ebab6f02"}, actual)
+	})
+
+	t.Run("generate increasing V7 UUIDs at fixed time", func(t *testing.T) {
+		// arrange
+		sut, err := generate.NewV7(generate.V7Options{Options: opts})
+
+		// act
+		actual := next(t, sut, 2)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, []string{
+			"01947961-e155-7622-853c-56e56f0235b4",
+			"01947961-e155-7622-853c-56e6de45925d",
+		}, actual)
+	})
+
+	t.Run("generate ULID from random source", func(t *testing.T) {
+		// arrange
+		var sut = generate.NewULID(generate.ULIDOptions{
+			Options: generate.Options{Random: bytes.NewReader(bytes.Repeat([]byte{0x42}, 10)), Now: fixed},
+		})
+
+		// act
+		actual := next(t, sut, 1)
+
+		// assert
+		assert.Equal(t, []string{"01947961-e155-4242-4242-424242424242"}, actual)
+	})
+
+	t.Run("return error for unsupported V7 method", func(t *testing.T) {
+		// act
+		_, err := generate.NewV7(generate.V7Options{Method: "sequence"})
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error for overflowing precision counter", func(t *testing.T) {
+		// arrange
+		sut, _ := generate.NewV7(generate.V7Options{Options: opts, Method: generate.MethodPrecision})
+
+		// act
+		var err error
+		for i := 0; i <= 1<<12 && err == nil; i++ {
+			_, err = sut.Next()
+		}
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("generate V6 UUID at time after 2262", func(t *testing.T) {
+		// arrange
+		var (
+			at          = time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC)
+			sut         = generate.NewV6(generate.Options{Seed: "lib", Now: func() time.Time { return at }})
+			lower, _, _ = generate.Bounds(6, at, at)
+		)
+
+		// act
+		actual, err := sut.Next()
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, lower.Bytes()[:8], actual.Bytes()[:8])
+	})
+
+	t.Run("return error for V1 time before the Gregorian calendar", func(t *testing.T) {
+		// arrange
+		var sut = generate.NewV1(generate.Options{Seed: "lib", Now: func() time.Time { return time.Date(1500, 1, 1, 0, 0, 0, 0, time.UTC) }})
+
+		// act
+		_, err := sut.Next()
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error for V6 time after 5236", func(t *testing.T) {
+		// arrange
+		var sut = generate.NewV6(generate.Options{Seed: "lib", Now: func() time.Time { return time.Date(6000, 1, 1, 0, 0, 0, 0, time.UTC) }})
+
+		// act
+		_, err := sut.Next()
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error for V7 time before 1970", func(t *testing.T) {
+		// arrange
+		before := func() time.Time { return time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC) }
+		sut, _ := generate.NewV7(generate.V7Options{Options: generate.Options{Seed: "lib", Now: before}})
+
+		// act
+		_, err := sut.Next()
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+func TestResolveNamespace(t *testing.T) {
+	t.Run("resolve alias with path", func(t *testing.T) {
+		// act
+		ns, err := generate.ResolveNamespace("dns:example.com/tenants", nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "8273e800-aa83-551e-af00-1ff9f31e632e", uuid.NewV5(ns, "hi").String())
+	})
+
+	t.Run("resolve named namespace", func(t *testing.T) {
+		// act
+		ns, err := generate.ResolveNamespace("orders", map[string]string{"orders": uuid.NamespaceURL.String()})
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, uuid.NamespaceURL, ns)
+	})
+
+	t.Run("return error for unknown namespace", func(t *testing.T) {
+		// act
+		_, err := generate.ResolveNamespace("orders", nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+func TestParseTime(t *testing.T) {
+	var tests = []struct {
+		value    string
+		expected time.Time
+	}{
+		{value: "2025-01-18T12:27:25.397Z", expected: epoch},
+		{value: "1737203245397", expected: epoch},
+		{value: "1737203245.397", expected: epoch},
+		{value: "1737203245397.5", expected: epoch.Add(500 * time.Microsecond)},
+		{value: "-0.5", expected: time.Unix(0, 0).Add(-500 * time.Millisecond)},
+		{value: " 2025-01-18T12:27:25.397Z ", expected: epoch},
+		{value: "0", expected: time.Unix(0, 0)},
+		{value: "-1d12h", expected: epoch.Add(-36 * time.Hour)},
+		{value: "today+2h", expected: time.Date(2025, 1, 18, 2, 0, 0, 0, time.UTC)},
+	}
+
+	for _, test := range tests {
+		t.Run("parse "+test.value, func(t *testing.T) {
+			// act
+			actual, err := generate.ParseTime(test.value, epoch, time.UTC)
+
+			// assert
+			assert.NoError(t, err)
+			assert.Equal(t, true, test.expected.Equal(actual))
+		})
+	}
+
+	for _, value := range []string{"20240101", "2024", "-86400"} {
+		t.Run("return error for ambiguous "+value, func(t *testing.T) {
+			// act
+			_, err := generate.ParseTime(value, epoch, time.UTC)
+
+			// assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestBounds(t *testing.T) {
+	t.Run("return V7 bounds of the millisecond", func(t *testing.T) {
+		// act
+		lower, upper, err := generate.Bounds(7, epoch, epoch)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "01947961-e155-7000-8000-000000000000", lower.String())
+		assert.Equal(t, "01947961-e155-7fff-bfff-ffffffffffff", upper.String())
+	})
+
+	t.Run("return V6 bounds of time after 2262", func(t *testing.T) {
+		// arrange
+		from := time.Date(2300, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		// act
+		lower, _, err := generate.Bounds(6, from, from)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "32416b50-4c0c-6000-8000-000000000000", lower.String())
+	})
+
+	t.Run("return error for V7 time before 1970", func(t *testing.T) {
+		// act
+		_, _, err := generate.Bounds(7, time.Date(1960, 1, 1, 0, 0, 0, 0, time.UTC), epoch)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error for V1 time before the Gregorian calendar", func(t *testing.T) {
+		// act
+		_, _, err := generate.Bounds(1, time.Date(1582, 10, 14, 0, 0, 0, 0, time.UTC), epoch)
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error for V6 time after 5236", func(t *testing.T) {
+		// act
+		_, _, err := generate.Bounds(6, epoch, time.Date(5300, 1, 1, 0, 0, 0, 0, time.UTC))
+
+		// assert
+		assert.Error(t, err)
+	})
+
+	t.Run("return error for version without time", func(t *testing.T) {
+		// act
+		_, _, err := generate.Bounds(4, epoch, epoch)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+func TestConvertVersion(t *testing.T) {
+	t.Run("convert V1 to V6 and back", func(t *testing.T) {
+		// arrange
+		v1, _ := generate.NewV1(generate.Options{Seed: "lib", Now: fixed}).Next()
+
+		// act
+		v6, toErr := generate.ConvertVersion(v1, 6)
+		actual, fromErr := generate.ConvertVersion(v6, 1)
+
+		// assert
+		assert.NoError(t, toErr)
+		assert.NoError(t, fromErr)
+		assert.Equal(t, uint8(6), v6.Version())
+		assert.Equal(t, v1, actual)
+	})
+}
+
+func TestPayload(t *testing.T) {
+	t.Run("pack and unpack fields of layout", func(t *testing.T) {
+		// arrange
+		fields, layoutErr := generate.ParseLayout("shard:16,tenant:32")
+
+		// act
+		payload, packErr := generate.PackPayload(fields, map[string]string{"shard": "3", "tenant": "0x2a"})
+		value, err := generate.NewV8(payload)
+		unpacked := generate.UnpackPayload(fields, payload)
+
+		// assert
+		assert.NoError(t, layoutErr)
+		assert.NoError(t, packErr)
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(8), value.Version())
+		assert.Equal(t, "3", unpacked[0].String())
+		assert.Equal(t, "42", unpacked[1].String())
+	})
+}
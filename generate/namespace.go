@@ -0,0 +1,75 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// namespaceAliases are the namespaces predefined by RFC 9562.
+var namespaceAliases = map[string]uuid.UUID{
+	"dns":  uuid.NamespaceDNS,
+	"url":  uuid.NamespaceURL,
+	"oid":  uuid.NamespaceOID,
+	"x500": uuid.NamespaceX500,
+}
+
+// ResolveNamespace resolves a namespace given as a UUID, as one of the
+// built-in aliases or as one of the named namespaces, optionally
+// followed by a path of names, e.g. "dns:example.com/tenants/acme". Each name
+// in the path is derived with V5 from the namespace before it.
+func ResolveNamespace(value string, named map[string]string) (uuid.UUID, error) {
+	if ns, err := uuid.FromString(value); err == nil {
+		return ns, nil
+	}
+
+	base, path, _ := strings.Cut(value, ":")
+
+	ns, err := resolveBaseNamespace(base, named)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	return deriveNamespace(ns, path)
+}
+
+func resolveBaseNamespace(base string, named map[string]string) (uuid.UUID, error) {
+	if ns, ok := namespaceAliases[strings.ToLower(base)]; ok {
+		return ns, nil
+	}
+
+	if ns, err := uuid.FromString(base); err == nil {
+		return ns, nil
+	}
+
+	expr, ok := named[base]
+	if !ok {
+		return uuid.Nil, fmt.Errorf("unknown namespace %q", base)
+	}
+
+	// named namespaces may only derive from UUIDs and the built-in aliases,
+	// which rules out cycles between them
+	ns, err := ResolveNamespace(expr, nil)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("namespace %q: %w", base, err)
+	}
+
+	return ns, nil
+}
+
+func deriveNamespace(ns uuid.UUID, path string) (uuid.UUID, error) {
+	if path == "" {
+		return ns, nil
+	}
+
+	for _, name := range strings.Split(path, "/") {
+		if name == "" {
+			return uuid.Nil, fmt.Errorf("empty name in namespace path %q", path)
+		}
+
+		ns = uuid.NewV5(ns, name)
+	}
+
+	return ns, nil
+}
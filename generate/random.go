@@ -0,0 +1,42 @@
+package generate
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"io"
+)
+
+// seededReader is a deterministic stream of pseudorandom bytes, produced by
+// hashing the seed together with a block counter (SHA-256 in counter mode).
+type seededReader struct {
+	input   []byte
+	counter uint64
+	sum     [sha256.Size]byte
+	block   []byte
+}
+
+// NewSeededReader returns a reader of the stream of the seed. The hashed input
+// is the seed followed by the 8 byte counter, which is updated in place.
+func NewSeededReader(seed string) io.Reader {
+	return &seededReader{input: append([]byte(seed), make([]byte, 8)...)}
+}
+
+func (r *seededReader) Read(p []byte) (int, error) {
+	var n int
+
+	for n < len(p) {
+		if len(r.block) == 0 {
+			binary.BigEndian.PutUint64(r.input[len(r.input)-8:], r.counter)
+			r.counter++
+
+			r.sum = sha256.Sum256(r.input)
+			r.block = r.sum[:]
+		}
+
+		copied := copy(p[n:], r.block)
+		r.block = r.block[copied:]
+		n += copied
+	}
+
+	return n, nil
+}
@@ -0,0 +1,147 @@
+package generate
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayouts lists the accepted layouts of absolute times. Times without a
+// zone are in the location given to ParseTime.
+var timeLayouts = []string{
+	time.RFC3339Nano,
+	"2006-01-02T15:04:05.999999999",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// minUnixDigits is the minimum number of digits of a whole unix timestamp.
+const minUnixDigits = 9
+
+var (
+	unixPattern = regexp.MustCompile(`^-?\d+(\.\d+)?$`)
+	daysPattern = regexp.MustCompile(`(\d+(?:\.\d+)?)d`)
+)
+
+// ParseTime parses the value as one of:
+//
+//   - a time in RFC 3339, with or without a zone, or a date
+//   - a unix timestamp in seconds, milliseconds, microseconds or
+//     nanoseconds, told apart by the number of digits of the integer part and
+//     optionally with a fraction of the unit. Whole timestamps need at least 9
+//     digits, so compact dates such as 20240101 are not mistaken for one
+//   - now, today, yesterday or tomorrow, optionally followed by an offset,
+//     e.g. now+15m or today-2h
+//   - an offset from now, e.g. -2h or +1d12h
+//
+// Dates, and times without a zone, are in the location loc.
+func ParseTime(value string, now time.Time, loc *time.Location) (time.Time, error) {
+	var (
+		trimmed = strings.TrimSpace(value)
+		lower   = strings.ToLower(trimmed)
+	)
+
+	if unixPattern.MatchString(lower) {
+		return parseUnix(lower)
+	}
+
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, trimmed, loc); err == nil {
+			return t, nil
+		}
+	}
+
+	var (
+		base   = now
+		offset = lower
+	)
+
+	year, month, day := now.In(loc).Date()
+	for keyword, days := range map[string]int{"today": 0, "yesterday": -1, "tomorrow": 1} {
+		if strings.HasPrefix(lower, keyword) {
+			base = time.Date(year, month, day+days, 0, 0, 0, 0, loc)
+			offset = strings.TrimPrefix(lower, keyword)
+		}
+	}
+	offset = strings.TrimPrefix(offset, "now")
+
+	if offset == "" {
+		return base, nil
+	}
+
+	if offset[0] != '+' && offset[0] != '-' {
+		return time.Time{}, fmt.Errorf("unable to parse %q: expected RFC 3339, a date, a unix timestamp or a relative time such as -2h, yesterday or now+15m", value)
+	}
+
+	duration, err := ParseDuration(offset)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unable to parse offset of %q: %w", value, err)
+	}
+
+	return base.Add(duration), nil
+}
+
+// ParseDuration parses a duration like time.ParseDuration, also accepting days
+// as 24 hours.
+func ParseDuration(value string) (time.Duration, error) {
+	return time.ParseDuration(daysPattern.ReplaceAllStringFunc(value, func(days string) string {
+		n, _ := strconv.ParseFloat(strings.TrimSuffix(days, "d"), 64)
+		return strconv.FormatFloat(n*24, 'f', -1, 64) + "h"
+	}))
+}
+
+// parseUnix parses a unix timestamp, where the unit is told apart by the
+// number of digits of the integer part: 9 to 11 for seconds, 12 to 14 for
+// milliseconds, 15 to 17 for microseconds and more for nanoseconds. A fraction
+// is a fraction of the unit, e.g. 1737203245397.5 is half a millisecond past
+// 1737203245397 ms. Shorter whole timestamps, other than 0, are rejected as
+// they are more likely a compact date or a year than a time in 1970.
+func parseUnix(value string) (time.Time, error) {
+	var (
+		integer, fraction, _ = strings.Cut(value, ".")
+		digits               = len(strings.TrimPrefix(integer, "-"))
+	)
+
+	n, err := strconv.ParseInt(integer, 10, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid unix timestamp %q: %w", value, err)
+	}
+
+	if n != 0 && fraction == "" && digits < minUnixDigits {
+		return time.Time{}, fmt.Errorf("ambiguous unix timestamp %q: use at least %d digits, or a date such as 2024-01-01", value, minUnixDigits)
+	}
+
+	var (
+		t    time.Time
+		unit time.Duration
+	)
+
+	switch {
+	case digits <= 11:
+		t, unit = time.Unix(n, 0), time.Second
+	case digits <= 14:
+		t, unit = time.UnixMilli(n), time.Millisecond
+	case digits <= 17:
+		t, unit = time.UnixMicro(n), time.Microsecond
+	default:
+		t, unit = time.Unix(0, n), time.Nanosecond
+	}
+
+	if fraction != "" {
+		// the fraction is read to a precision of a nanosecond of a second
+		nanos, _ := strconv.ParseInt((fraction + "000000000")[:9], 10, 64)
+
+		offset := time.Duration(nanos) * unit / time.Second
+		if strings.HasPrefix(integer, "-") {
+			offset = -offset
+		}
+
+		t = t.Add(offset)
+	}
+
+	return t, nil
+}
@@ -0,0 +1,89 @@
+package generate
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const maxULIDTime = 1<<48 - 1
+
+// ulidGenerator generates ULIDs: a 48 bit unix timestamp in milliseconds
+// followed by 80 random bits. In monotonic mode, the random bits of a value
+// generated within the same millisecond as the previous one are the previous
+// random bits incremented by one, as described by the ULID specification. If
+// the clock goes backwards, the last millisecond is kept, and an error is
+// returned rather than wrapping around when the random bits overflow.
+type ulidGenerator struct {
+	monotonic bool
+	random    io.Reader
+	now       func() time.Time
+
+	started bool
+	ms      uint64
+	hi      uint16
+	lo      uint64
+	buf     [10]byte
+}
+
+// ULIDOptions configures a generator of ULIDs.
+type ULIDOptions struct {
+	Options
+	// Monotonic increments the random bits of values generated within the
+	// same millisecond as the previous one, keeping them sorted.
+	Monotonic bool
+}
+
+// NewULID returns a generator of ULIDs.
+func NewULID(opts ULIDOptions) Generator {
+	return &ulidGenerator{
+		monotonic: opts.Monotonic,
+		random:    opts.random(),
+		now:       opts.now(),
+	}
+}
+
+func (g *ulidGenerator) Next() (uuid.UUID, error) {
+	var now = g.now()
+	if now.UnixMilli() < 0 || now.UnixMilli() > maxULIDTime {
+		return uuid.Nil, fmt.Errorf("time %s is outside the range of a ULID", now.UTC().Format(time.RFC3339Nano))
+	}
+
+	var ms = uint64(now.UnixMilli())
+
+	if g.monotonic && g.started && ms <= g.ms {
+		g.lo++
+		if g.lo == 0 {
+			g.hi++
+			if g.hi == 0 {
+				return uuid.Nil, fmt.Errorf("random bits overflow: too many values within a millisecond")
+			}
+		}
+	} else {
+		if _, err := io.ReadFull(g.random, g.buf[:]); err != nil {
+			return uuid.Nil, fmt.Errorf("reading random bits: %w", err)
+		}
+
+		g.ms = ms
+		g.hi = binary.BigEndian.Uint16(g.buf[0:2])
+		g.lo = binary.BigEndian.Uint64(g.buf[2:10])
+	}
+
+	g.started = true
+
+	return composeULID(g.ms, g.hi, g.lo), nil
+}
+
+// composeULID lays out the 48 bit timestamp and the 80 random bits, of which
+// hi holds the leading 16 bits.
+func composeULID(ms uint64, hi uint16, lo uint64) uuid.UUID {
+	var u uuid.UUID
+
+	binary.BigEndian.PutUint64(u[0:8], ms<<16|uint64(hi))
+	binary.BigEndian.PutUint64(u[8:16], lo)
+
+	return u
+}
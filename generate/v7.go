@@ -0,0 +1,227 @@
+package generate
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+const (
+	MethodCounter   = "counter"
+	MethodRandom    = "random"
+	MethodPrecision = "precision"
+
+	MinCounterBits = 12
+	MaxCounterBits = 42
+)
+
+// V7Methods lists the supported methods of keeping V7 UUIDs monotonic.
+var V7Methods = []string{
+	MethodCounter,
+	MethodRandom,
+	MethodPrecision,
+}
+
+const (
+	randABits = 12
+	randBBits = 62
+)
+
+// v7Generator generates V7 UUIDs in strictly increasing order, using one of
+// the methods of RFC 9562, section 6.2:
+//
+//   - counter: a counter of counterBits bits, taking up rand_a and the leading
+//     bits of rand_b, which starts at a random value with the leftmost bit
+//     cleared in each millisecond and is incremented by one
+//   - random: the 74 bits of rand_a and rand_b start at a random value with the
+//     leftmost bit cleared in each millisecond and are incremented by a random
+//     value between 1 and 2^32
+//   - precision: rand_a holds the sub-millisecond fraction of the time in 12
+//     bits, which is incremented by one when the time does not advance
+//
+// If the clock goes backwards, the last millisecond is kept. Rather than
+// generating a value out of order, an error is returned when the counter
+// overflows.
+type v7Generator struct {
+	method      string
+	counterBits uint
+	random      io.Reader
+	now         func() time.Time
+
+	started bool
+	ms      uint64
+	counter uint64
+	randA   uint64
+	randB   uint64
+	buf     [8]byte
+}
+
+// V7Options configures a generator of V7 UUIDs.
+type V7Options struct {
+	Options
+	// Method is the method keeping the values monotonic, MethodCounter if
+	// empty.
+	Method string
+	// CounterBits is the length of the counter of MethodCounter,
+	// MaxCounterBits if zero.
+	CounterBits uint
+}
+
+// NewV7 returns a generator of V7 UUIDs, which are strictly increasing.
+func NewV7(opts V7Options) (Generator, error) {
+	var (
+		method      = opts.Method
+		counterBits = opts.CounterBits
+	)
+
+	if method == "" {
+		method = MethodCounter
+	}
+
+	if counterBits == 0 {
+		counterBits = MaxCounterBits
+	}
+
+	return newV7Generator(method, counterBits, opts.random(), opts.now())
+}
+
+func newV7Generator(method string, counterBits uint, random io.Reader, now func() time.Time) (*v7Generator, error) {
+	switch method {
+	case MethodCounter:
+		if counterBits < MinCounterBits || counterBits > MaxCounterBits {
+			return nil, fmt.Errorf("invalid counter bits %d: must be between %d and %d", counterBits, MinCounterBits, MaxCounterBits)
+		}
+	case MethodRandom, MethodPrecision:
+	default:
+		return nil, fmt.Errorf("unsupported method %q", method)
+	}
+
+	return &v7Generator{
+		method:      method,
+		counterBits: counterBits,
+		random:      random,
+		now:         now,
+	}, nil
+}
+
+// maxV7Time is the largest 48 bit unix timestamp in milliseconds of a V7 UUID.
+const maxV7Time = 1<<48 - 1
+
+// v7Time returns the unix timestamp in milliseconds of the time, or an error if
+// the time is outside the range of V7 UUIDs (1970 to 10889).
+func v7Time(t time.Time) (uint64, error) {
+	var ms = t.UnixMilli()
+	if ms < 0 || ms > maxV7Time {
+		return 0, fmt.Errorf("time %s is outside the range of V7 UUIDs", t.UTC().Format(time.RFC3339Nano))
+	}
+
+	return uint64(ms), nil
+}
+
+func (g *v7Generator) Next() (uuid.UUID, error) {
+	var now = g.now()
+
+	ms, err := v7Time(now)
+	if err != nil {
+		return uuid.Nil, err
+	}
+
+	// a millisecond before the last one is treated as the last one, so the
+	// values keep increasing when the clock goes backwards
+	var advanced = !g.started || ms > g.ms
+
+	switch g.method {
+	case MethodCounter:
+		var extraBits = g.counterBits - randABits
+
+		if advanced {
+			if g.counter, err = g.randomBits(g.counterBits - 1); err != nil {
+				return uuid.Nil, err
+			}
+			g.ms = ms
+		} else {
+			g.counter++
+			if g.counter >= 1<<g.counterBits {
+				return uuid.Nil, fmt.Errorf("counter overflow: too many values within a millisecond for a %d bit counter", g.counterBits)
+			}
+		}
+
+		low, err := g.randomBits(randBBits - extraBits)
+		if err != nil {
+			return uuid.Nil, err
+		}
+
+		g.randA = g.counter >> extraBits
+		g.randB = (g.counter&(1<<extraBits-1))<<(randBBits-extraBits) | low
+	case MethodRandom:
+		if advanced {
+			if g.randA, err = g.randomBits(randABits - 1); err != nil {
+				return uuid.Nil, err
+			}
+			if g.randB, err = g.randomBits(randBBits); err != nil {
+				return uuid.Nil, err
+			}
+			g.ms = ms
+		} else {
+			increment, err := g.randomBits(32)
+			if err != nil {
+				return uuid.Nil, err
+			}
+
+			g.randB += increment + 1
+			if g.randB >= 1<<randBBits {
+				g.randB -= 1 << randBBits
+				g.randA++
+			}
+
+			if g.randA >= 1<<randABits {
+				return uuid.Nil, fmt.Errorf("counter overflow: too many values within a millisecond for random increments")
+			}
+		}
+	case MethodPrecision:
+		var (
+			fraction = uint64(now.Nanosecond()%int(time.Millisecond)) << randABits / uint64(time.Millisecond)
+			ts       = ms<<randABits | fraction
+			last     = g.ms<<randABits | g.randA
+		)
+
+		if g.started && ts <= last {
+			ts = last + 1
+			if ts>>randABits != g.ms {
+				return uuid.Nil, fmt.Errorf("counter overflow: more than %d values within a millisecond", 1<<randABits)
+			}
+		}
+
+		g.ms, g.randA = ts>>randABits, ts&(1<<randABits-1)
+		if g.randB, err = g.randomBits(randBBits); err != nil {
+			return uuid.Nil, err
+		}
+	}
+
+	g.started = true
+
+	return composeV7(g.ms, g.randA, g.randB), nil
+}
+
+// randomBits returns a random value of the given number of bits, at most 64.
+func (g *v7Generator) randomBits(bits uint) (uint64, error) {
+	if _, err := io.ReadFull(g.random, g.buf[:]); err != nil {
+		return 0, fmt.Errorf("reading random bits: %w", err)
+	}
+
+	return binary.BigEndian.Uint64(g.buf[:]) >> (64 - bits), nil
+}
+
+// composeV7 lays out the 48 bit timestamp, the 12 bits of rand_a and the 62
+// bits of rand_b with the version and variant bits.
+func composeV7(ms, randA, randB uint64) uuid.UUID {
+	var u uuid.UUID
+
+	binary.BigEndian.PutUint64(u[0:8], ms<<16|uint64(uuid.V7)<<12|randA)
+	binary.BigEndian.PutUint64(u[8:16], 1<<63|randB)
+
+	return u
+}
@@ -0,0 +1,171 @@
+package generate
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/gofrs/uuid/v5"
+)
+
+// PayloadBits is the number of custom bits available in a V8 UUID, i.e. the
+// 128 bits minus the 4 version bits and the 2 variant bits.
+const PayloadBits = 122
+
+// Field is a named bit field of the payload of a V8 UUID.
+type Field struct {
+	Name string
+	Bits int
+}
+
+// ParseLayout parses a layout spec on the form "name:bits,name:bits". Fields
+// are packed from the most significant bit of the payload.
+func ParseLayout(spec string) ([]Field, error) {
+	var (
+		fields []Field
+		total  int
+		seen   = map[string]bool{}
+	)
+
+	for _, part := range strings.Split(spec, ",") {
+		name, bitsStr, ok := strings.Cut(strings.TrimSpace(part), ":")
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid layout field %q: expected name:bits", part)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate layout field %q", name)
+		}
+
+		bits, err := strconv.Atoi(bitsStr)
+		if err != nil || bits <= 0 {
+			return nil, fmt.Errorf("invalid number of bits for layout field %q", name)
+		}
+
+		total += bits
+		if total > PayloadBits {
+			return nil, fmt.Errorf("layout exceeds %d bits", PayloadBits)
+		}
+
+		seen[name] = true
+		fields = append(fields, Field{Name: name, Bits: bits})
+	}
+
+	return fields, nil
+}
+
+// PackPayload packs the field values into a payload according to the layout.
+// Fields without a value are set to zero.
+func PackPayload(fields []Field, values map[string]string) (*big.Int, error) {
+	var (
+		payload = new(big.Int)
+		offset  = PayloadBits
+		known   = map[string]bool{}
+	)
+
+	for _, f := range fields {
+		known[f.Name] = true
+		offset -= f.Bits
+
+		str, ok := values[f.Name]
+		if !ok {
+			continue
+		}
+
+		value, err := parseBigInt(str)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value for field %q: %w", f.Name, err)
+		}
+
+		if value.BitLen() > f.Bits {
+			return nil, fmt.Errorf("value for field %q exceeds %d bits", f.Name, f.Bits)
+		}
+
+		payload.Or(payload, new(big.Int).Lsh(value, uint(offset)))
+	}
+
+	for name := range values {
+		if !known[name] {
+			return nil, fmt.Errorf("field %q is not part of the layout", name)
+		}
+	}
+
+	return payload, nil
+}
+
+// UnpackPayload extracts the field values from a payload according to the
+// layout.
+func UnpackPayload(fields []Field, payload *big.Int) []*big.Int {
+	var (
+		values = make([]*big.Int, 0, len(fields))
+		offset = PayloadBits
+	)
+
+	for _, f := range fields {
+		offset -= f.Bits
+
+		mask := new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), uint(f.Bits)), big.NewInt(1))
+		value := new(big.Int).Rsh(payload, uint(offset))
+		values = append(values, value.And(value, mask))
+	}
+
+	return values
+}
+
+// ParseData parses the payload of a V8 UUID given as hex, optionally prefixed
+// with 0x.
+func ParseData(data string) (*big.Int, error) {
+	return parseBigInt("0x" + strings.TrimPrefix(strings.ToLower(data), "0x"))
+}
+
+// parseBigInt parses a non-negative decimal or 0x-prefixed hex value.
+func parseBigInt(s string) (*big.Int, error) {
+	var (
+		value = new(big.Int)
+		ok    bool
+	)
+
+	if hex, found := strings.CutPrefix(strings.ToLower(s), "0x"); found {
+		_, ok = value.SetString(hex, 16)
+	} else {
+		_, ok = value.SetString(s, 10)
+	}
+
+	if !ok || value.Sign() < 0 {
+		return nil, fmt.Errorf("%q is not a non-negative integer", s)
+	}
+
+	return value, nil
+}
+
+// NewV8 returns a V8 UUID with the payload spread over the custom_a (48 bits),
+// custom_b (12 bits) and custom_c (62 bits) fields.
+func NewV8(payload *big.Int) (uuid.UUID, error) {
+	var u uuid.UUID
+
+	if payload.BitLen() > PayloadBits {
+		return uuid.Nil, fmt.Errorf("payload exceeds %d bits", PayloadBits)
+	}
+
+	var raw [16]byte
+	payload.FillBytes(raw[:])
+
+	var (
+		hi = binary.BigEndian.Uint64(raw[:8])
+		lo = binary.BigEndian.Uint64(raw[8:])
+
+		customAB = hi<<2 | lo>>62 // upper 60 bits of the payload
+		customC  = lo & (1<<62 - 1)
+	)
+
+	binary.BigEndian.PutUint64(u[0:8], customAB<<4)
+	binary.BigEndian.PutUint16(u[6:8], uint16(customAB&0xfff))
+	binary.BigEndian.PutUint64(u[8:16], customC)
+
+	u.SetVersion(8)
+	u.SetVariant(uuid.VariantRFC9562)
+
+	return u, nil
+}
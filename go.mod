@@ -5,9 +5,8 @@ go 1.21.1
 require (
 	github.com/gofrs/uuid/v5 v5.3.0
 	github.com/spf13/cobra v1.8.1
+	github.com/spf13/pflag v1.0.6
+	gopkg.in/yaml.v3 v3.0.1
 )
 
-require (
-	github.com/inconshreveable/mousetrap v1.1.0 // indirect
-	github.com/spf13/pflag v1.0.6 // indirect
-)
+require github.com/inconshreveable/mousetrap v1.1.0 // indirect
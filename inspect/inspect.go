@@ -0,0 +1,199 @@
+// Package inspect breaks UUIDs, ULIDs and TypeIDs down into the fields of
+// their version, and writes the result as text, JSON, YAML or environment
+// variables.
+package inspect
+
+import (
+	"encoding/binary"
+	"math/big"
+	"net"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/codec"
+	"github.com/legaard/uuidy/generate"
+)
+
+// ParseResult holds the details of a parsed UUID. Fields that do not apply to
+// the version of the UUID are left as nil.
+type ParseResult struct {
+	Value uuid.UUID
+	// ULID is set when the value was given as a ULID.
+	ULID bool
+	// TypeID is the TypeID the value was given as.
+	TypeID string
+	// Special is "nil" or "max" for the special UUIDs.
+	Special string
+	// Time is the time of the timestamp of V1, V6 and V7 UUIDs and ULIDs.
+	Time *time.Time
+	// ClockSeq is the clock sequence of V1 and V6 UUIDs.
+	ClockSeq *uint16
+	// Node is the node of V1 and V6 UUIDs.
+	Node net.HardwareAddr
+	// RandA and RandB are the random bits of V7 UUIDs, before and after the
+	// variant.
+	RandA *uint16
+	RandB *uint64
+	// Random is the random bits of V4 and V7 UUIDs and ULIDs.
+	Random *big.Int
+	// Hash is the hash bits of V3 and V5 UUIDs.
+	Hash *big.Int
+	// Payload is the custom bits of V8 UUIDs.
+	Payload *big.Int
+	// Fields and FieldValues are the layout of the payload of a V8 UUID and
+	// the value of each field.
+	Fields      []generate.Field
+	FieldValues []*big.Int
+}
+
+// Parse decodes the value with the encoding and returns its details, where
+// the fields lay out the payload of a V8 UUID. As no textual UUID format
+// shares the length of a ULID, or contains the underscore of a prefixed
+// TypeID, both are also accepted where the canonical format is expected.
+func Parse(value string, enc codec.Encoding, fields []generate.Field) (ParseResult, error) {
+	var format = enc.Format
+
+	if (format == codec.Auto || codec.Resolve(format) == codec.Canonical) && codec.IsTypeID(value) {
+		prefix, decoded, err := codec.DecodeTypeID(value)
+		if err != nil {
+			return ParseResult{}, err
+		}
+
+		result := Inspect(decoded, fields)
+		result.TypeID = codec.EncodeTypeID(prefix, decoded)
+
+		return result, nil
+	}
+
+	if format == codec.Auto {
+		detected, err := codec.Detect(value)
+		if err != nil {
+			return ParseResult{}, err
+		}
+
+		format = detected
+	}
+
+	if codec.Resolve(format) == codec.Canonical && len(value) == codec.ULIDLength {
+		format = codec.Crockford
+	}
+
+	decoded, err := codec.Encoding{Format: format, Order: enc.Order}.Decode(value)
+	if err != nil {
+		return ParseResult{}, err
+	}
+
+	if codec.Resolve(format) == codec.Crockford {
+		return InspectULID(decoded), nil
+	}
+
+	return Inspect(decoded, fields), nil
+}
+
+// Inspect returns the details of the UUID, where the fields lay out the
+// payload of a V8 UUID.
+func Inspect(value uuid.UUID, fields []generate.Field) ParseResult {
+	var result = ParseResult{Value: value}
+
+	switch value {
+	case uuid.Nil:
+		result.Special = "nil"
+		return result
+	case uuid.Max:
+		result.Special = "max"
+		return result
+	}
+
+	switch value.Version() {
+	case 1:
+		v1, _ := uuid.TimestampFromV1(value)
+		ts, _ := v1.Time()
+
+		result.Time = &ts
+		result.ClockSeq = clockSeqOf(value)
+		result.Node = net.HardwareAddr(value[10:])
+	case 3, 5:
+		result.Hash = customBits(value)
+	case 4:
+		result.Random = customBits(value)
+	case 6:
+		v6, _ := uuid.TimestampFromV6(value)
+		ts, _ := v6.Time()
+
+		result.Time = &ts
+		result.ClockSeq = clockSeqOf(value)
+		result.Node = net.HardwareAddr(value[10:])
+	case 7:
+		v7, _ := uuid.TimestampFromV7(value)
+		ts, _ := v7.Time()
+
+		var (
+			randA = binary.BigEndian.Uint16(value[6:8]) & 0xfff
+			randB = binary.BigEndian.Uint64(value[8:16]) & (1<<62 - 1)
+		)
+
+		result.Time = &ts
+		result.RandA = &randA
+		result.RandB = &randB
+		result.Random = new(big.Int).Lsh(big.NewInt(int64(randA)), 62)
+		result.Random.Or(result.Random, new(big.Int).SetUint64(randB))
+	case 8:
+		result.Payload = customBits(value)
+		result.Fields = fields
+		result.FieldValues = generate.UnpackPayload(fields, result.Payload)
+	}
+
+	return result
+}
+
+// InspectULID returns the details of a ULID: the time of its 48 bit
+// timestamp and its 80 random bits. Being a ULID, the version and variant
+// bits are part of the random bits.
+func InspectULID(value uuid.UUID) ParseResult {
+	var (
+		ms = binary.BigEndian.Uint64(value[0:8]) >> 16
+		ts = time.UnixMilli(int64(ms))
+	)
+
+	return ParseResult{
+		Value:  value,
+		ULID:   true,
+		Time:   &ts,
+		Random: new(big.Int).SetBytes(value[6:]),
+	}
+}
+
+func clockSeqOf(value uuid.UUID) *uint16 {
+	clockSeq := binary.BigEndian.Uint16(value[8:10]) & 0x3fff
+	return &clockSeq
+}
+
+// VariantName returns the name of the variant of the UUID: ncs, rfc9562,
+// microsoft or future.
+func VariantName(value uuid.UUID) string {
+	switch value.Variant() {
+	case uuid.VariantNCS:
+		return "ncs"
+	case uuid.VariantRFC9562:
+		return "rfc9562"
+	case uuid.VariantMicrosoft:
+		return "microsoft"
+	default:
+		return "future"
+	}
+}
+
+// customBits returns the 122 bits of a UUID that are not taken by the version
+// and variant, i.e. the payload of a V8 UUID or the random bits of a V4 UUID.
+func customBits(u uuid.UUID) *big.Int {
+	var (
+		customA = binary.BigEndian.Uint64(u[0:8]) >> 16
+		customB = uint64(binary.BigEndian.Uint16(u[6:8]) & 0xfff)
+		customC = binary.BigEndian.Uint64(u[8:16]) & (1<<62 - 1)
+	)
+
+	payload := new(big.Int).SetUint64(customA<<12 | customB)
+	payload.Lsh(payload, 62)
+
+	return payload.Or(payload, new(big.Int).SetUint64(customC))
+}
@@ -0,0 +1,138 @@
+package inspect_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofrs/uuid/v5"
+	"github.com/legaard/uuidy/codec"
+	"github.com/legaard/uuidy/generate"
+	"github.com/legaard/uuidy/inspect"
+	"github.com/legaard/uuidy/internal/assert"
+)
+
+var canonical = codec.Encoding{Format: codec.Canonical, Order: codec.ByteOrderRFC}
+
+func TestParse(t *testing.T) {
+	t.Run("parse V7 UUID", func(t *testing.T) {
+		// act
+		result, err := inspect.Parse("01890a5d-ac96-774b-bcce-b302099a8057", canonical, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, uint8(7), result.Value.Version())
+		assert.Equal(t, "2023-06-30T03:34:18.518Z", result.Time.UTC().Format(time.RFC3339Nano))
+		assert.Equal(t, uint16(0x74b), *result.RandA)
+		assert.Equal(t, uint64(0x3cceb302099a8057), *result.RandB)
+		assert.Equal(t, false, result.ULID)
+	})
+
+	t.Run("parse TypeID", func(t *testing.T) {
+		// act
+		result, err := inspect.Parse("user_01h455vb4pex5vsknk084sn02q", canonical, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "01890a5d-ac96-774b-bcce-b302099a8057", result.Value.String())
+		assert.Equal(t, "user_01h455vb4pex5vsknk084sn02q", result.TypeID)
+	})
+
+	t.Run("parse ULID", func(t *testing.T) {
+		// act
+		result, err := inspect.Parse("01JHWP3RAN89144GJ289144GJ2", canonical, nil)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, true, result.ULID)
+		assert.Equal(t, int64(1737203245397), result.Time.UnixMilli())
+		assert.Equal(t, "42424242424242424242", result.Random.Text(16))
+	})
+
+	t.Run("parse fields of V8 UUID", func(t *testing.T) {
+		// arrange
+		fields, _ := generate.ParseLayout("shard:16,tenant:32")
+		payload, _ := generate.PackPayload(fields, map[string]string{"shard": "3", "tenant": "42"})
+		value, _ := generate.NewV8(payload)
+
+		// act
+		result, err := inspect.Parse(value.String(), canonical, fields)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, "3", result.FieldValues[0].String())
+		assert.Equal(t, "42", result.FieldValues[1].String())
+	})
+
+	t.Run("return error for invalid value", func(t *testing.T) {
+		// act
+		_, err := inspect.Parse("not a uuid", canonical, nil)
+
+		// assert
+		assert.Error(t, err)
+	})
+}
+
+func TestInspect(t *testing.T) {
+	t.Run("mark nil UUID as special", func(t *testing.T) {
+		// act
+		result := inspect.Inspect(uuid.Nil, nil)
+
+		// assert
+		assert.Equal(t, "nil", result.Special)
+		assert.Equal(t, (*time.Time)(nil), result.Time)
+	})
+
+	t.Run("return clock sequence and node of V1 UUID", func(t *testing.T) {
+		// arrange
+		var value = uuid.Must(uuid.FromString("57fd0000-c7d3-11ef-90ed-c362344620eb"))
+
+		// act
+		result := inspect.Inspect(value, nil)
+
+		// assert
+		assert.Equal(t, uint16(0x10ed), *result.ClockSeq)
+		assert.Equal(t, "c3:62:34:46:20:eb", result.Node.String())
+	})
+}
+
+func TestWrite(t *testing.T) {
+	t.Run("write ULID as JSON with all keys", func(t *testing.T) {
+		// arrange
+		var (
+			output    = &bytes.Buffer{}
+			result, _ = inspect.Parse("01JHWP3RAN89144GJ289144GJ2", canonical, nil)
+		)
+
+		// act
+		err := inspect.Write(output, inspect.OutputJSON, result)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, `{"uuid":"01947961-e155-4242-4242-424242424242","ulid":"01JHWP3RAN89144GJ289144GJ2","typeid":null,"special":null,"version":null,"variant":null,"time":"2025-01-18T12:27:25.397Z","unix_ms":1737203245397,"clock_seq":null,"node":null,"node_random":null,"rand_a":null,"rand_b":null,"random":"0x42424242424242424242","hash":null,"payload":null}`+"\n", output.String())
+	})
+
+	t.Run("write ULID with bits of V7 UUID without version and variant", func(t *testing.T) {
+		// arrange
+		var (
+			output    = &bytes.Buffer{}
+			result, _ = inspect.Parse("01JHWP3RANF8S85W8V4J8Z60DC", canonical, nil)
+		)
+
+		// act
+		err := inspect.Write(output, inspect.OutputEnv, result)
+
+		// assert
+		assert.NoError(t, err)
+		assert.Equal(t, true, strings.Contains(output.String(), "\nVERSION=\nVARIANT=\n"))
+	})
+
+	t.Run("return error for unsupported output", func(t *testing.T) {
+		// act
+		err := inspect.Write(&bytes.Buffer{}, "xml", inspect.Inspect(uuid.Nil, nil))
+
+		// assert
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,232 @@
+package inspect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/legaard/uuidy/codec"
+)
+
+const (
+	OutputText = "text"
+	OutputJSON = "json"
+	OutputYAML = "yaml"
+	OutputEnv  = "env"
+)
+
+// Outputs lists the supported outputs.
+var Outputs = []string{
+	OutputText,
+	OutputJSON,
+	OutputYAML,
+	OutputEnv,
+}
+
+// Entry is a key/value pair of a parse result. The value is either nil, a
+// string, an integer, a bool, a *big.Int or a nested list of entries.
+type Entry struct {
+	Key   string
+	Value any
+}
+
+// Entries returns the result as a list of entries with a stable set of keys,
+// regardless of the version of the UUID.
+func (r ParseResult) Entries() []Entry {
+	var (
+		ulid, version, variant, ts, unixMs, clockSeq any
+		node, nodeRandom, randA, randB, random       any
+		hash, payload                                any
+	)
+
+	// the bits of the version and variant of a ULID are part of its random
+	// component, so they have no meaning
+	if r.ULID {
+		ulid = codec.EncodeULID(r.Value)
+	} else {
+		version = int64(r.Value.Version())
+		variant = VariantName(r.Value)
+	}
+
+	if r.Time != nil {
+		ts = r.Time.UTC().Format(time.RFC3339Nano)
+		unixMs = r.Time.UnixMilli()
+	}
+
+	if r.ClockSeq != nil {
+		clockSeq = int64(*r.ClockSeq)
+	}
+
+	if r.Node != nil {
+		node = r.Node.String()
+		// the multicast bit marks a node ID that is not a real MAC address
+		nodeRandom = r.Node[0]&0x01 == 0x01
+	}
+
+	if r.RandA != nil {
+		randA = fmt.Sprintf("0x%03x", *r.RandA)
+	}
+
+	if r.RandB != nil {
+		randB = fmt.Sprintf("0x%016x", *r.RandB)
+	}
+
+	if r.Random != nil {
+		random = fmt.Sprintf("0x%x", r.Random)
+	}
+
+	if r.Hash != nil {
+		hash = fmt.Sprintf("0x%031x", r.Hash)
+	}
+
+	if r.Payload != nil {
+		payload = fmt.Sprintf("0x%031x", r.Payload)
+	}
+
+	var entries = []Entry{
+		{Key: "uuid", Value: r.Value.String()},
+		{Key: "ulid", Value: ulid},
+		{Key: "typeid", Value: nilIfEmpty(r.TypeID)},
+		{Key: "special", Value: nilIfEmpty(r.Special)},
+		{Key: "version", Value: version},
+		{Key: "variant", Value: variant},
+		{Key: "time", Value: ts},
+		{Key: "unix_ms", Value: unixMs},
+		{Key: "clock_seq", Value: clockSeq},
+		{Key: "node", Value: node},
+		{Key: "node_random", Value: nodeRandom},
+		{Key: "rand_a", Value: randA},
+		{Key: "rand_b", Value: randB},
+		{Key: "random", Value: random},
+		{Key: "hash", Value: hash},
+		{Key: "payload", Value: payload},
+	}
+
+	if len(r.Fields) > 0 {
+		var fields []Entry
+		for i, f := range r.Fields {
+			fields = append(fields, Entry{Key: f.Name, Value: r.FieldValues[i]})
+		}
+
+		entries = append(entries, Entry{Key: "fields", Value: fields})
+	}
+
+	return entries
+}
+
+func nilIfEmpty(s string) any {
+	if s == "" {
+		return nil
+	}
+
+	return s
+}
+
+// Write writes the parse result in one of the output formats.
+func Write(writer io.Writer, output string, result ParseResult) error {
+	var sb strings.Builder
+
+	switch output {
+	case OutputText:
+		writeText(&sb, result.Entries(), "")
+	case OutputJSON:
+		writeJSON(&sb, result.Entries())
+		sb.WriteString("\n")
+	case OutputYAML:
+		writeYAML(&sb, result.Entries(), "")
+	case OutputEnv:
+		writeEnv(&sb, result.Entries(), "")
+	default:
+		return fmt.Errorf("unsupported output format %q", output)
+	}
+
+	_, err := io.WriteString(writer, sb.String())
+
+	return err
+}
+
+// WriteSeparator writes the separator between two results written in sequence.
+func WriteSeparator(writer io.Writer, output string) error {
+	var sep string
+
+	switch output {
+	case OutputText, OutputEnv:
+		sep = "\n"
+	case OutputYAML:
+		sep = "---\n"
+	}
+
+	_, err := io.WriteString(writer, sep)
+
+	return err
+}
+
+// writeText writes the entries that apply to the UUID, leaving out the null
+// ones.
+func writeText(sb *strings.Builder, entries []Entry, indent string) {
+	for _, e := range entries {
+		switch value := e.Value.(type) {
+		case nil:
+			continue
+		case []Entry:
+			sb.WriteString(indent + e.Key + ":\n")
+			writeText(sb, value, indent+"  ")
+		default:
+			sb.WriteString(fmt.Sprintf("%s%s: %v\n", indent, e.Key, value))
+		}
+	}
+}
+
+func writeJSON(sb *strings.Builder, entries []Entry) {
+	sb.WriteString("{")
+	for i, e := range entries {
+		if i > 0 {
+			sb.WriteString(",")
+		}
+
+		key, _ := json.Marshal(e.Key)
+		sb.Write(key)
+		sb.WriteString(":")
+
+		if nested, ok := e.Value.([]Entry); ok {
+			writeJSON(sb, nested)
+			continue
+		}
+
+		value, _ := json.Marshal(e.Value)
+		sb.Write(value)
+	}
+	sb.WriteString("}")
+}
+
+func writeYAML(sb *strings.Builder, entries []Entry, indent string) {
+	for _, e := range entries {
+		if nested, ok := e.Value.([]Entry); ok {
+			sb.WriteString(indent + e.Key + ":\n")
+			writeYAML(sb, nested, indent+"  ")
+			continue
+		}
+
+		// JSON scalars are valid YAML, and quoting strings keeps values
+		// such as timestamps from being interpreted by YAML parsers.
+		value, _ := json.Marshal(e.Value)
+		sb.WriteString(indent + e.Key + ": " + string(value) + "\n")
+	}
+}
+
+func writeEnv(sb *strings.Builder, entries []Entry, prefix string) {
+	for _, e := range entries {
+		var key = prefix + strings.ToUpper(e.Key)
+
+		switch value := e.Value.(type) {
+		case []Entry:
+			writeEnv(sb, value, key+"_")
+		case nil:
+			sb.WriteString(key + "=\n")
+		default:
+			sb.WriteString(fmt.Sprintf("%s=%v\n", key, value))
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"os"
 
 	"github.com/legaard/uuidy/cmd"
@@ -13,6 +14,11 @@ var (
 
 func main() {
 	if err := cmd.Execute(version); err != nil {
-		os.Exit(-1)
+		var exitErr *cmd.ExitError
+		if errors.As(err, &exitErr) {
+			os.Exit(exitErr.Code)
+		}
+
+		os.Exit(cmd.ExitCodeError)
 	}
 }